@@ -13,7 +13,23 @@ import (
 type IAdminV1 interface {
 	ProviderCreate(ctx context.Context, req *v1.ProviderCreateReq) (res *v1.ProviderCreateRes, err error)
 	ProviderList(ctx context.Context, req *v1.ProviderListReq) (res *v1.ProviderListRes, err error)
+	ProviderKeyCreate(ctx context.Context, req *v1.ProviderKeyCreateReq) (res *v1.ProviderKeyCreateRes, err error)
+	ProviderKeyList(ctx context.Context, req *v1.ProviderKeyListReq) (res *v1.ProviderKeyListRes, err error)
+	ProviderKeyDelete(ctx context.Context, req *v1.ProviderKeyDeleteReq) (res *v1.ProviderKeyDeleteRes, err error)
+	ModelAliasCreate(ctx context.Context, req *v1.ModelAliasCreateReq) (res *v1.ModelAliasCreateRes, err error)
+	ModelAliasList(ctx context.Context, req *v1.ModelAliasListReq) (res *v1.ModelAliasListRes, err error)
+	ModelAliasUpdate(ctx context.Context, req *v1.ModelAliasUpdateReq) (res *v1.ModelAliasUpdateRes, err error)
+	ModelAliasDelete(ctx context.Context, req *v1.ModelAliasDeleteReq) (res *v1.ModelAliasDeleteRes, err error)
 	UserCreate(ctx context.Context, req *v1.UserCreateReq) (res *v1.UserCreateRes, err error)
 	UserDelete(ctx context.Context, req *v1.UserDeleteReq) (res *v1.UserDeleteRes, err error)
 	UserGetList(ctx context.Context, req *v1.UserGetListReq) (res *v1.UserGetListRes, err error)
+	McpServerCreate(ctx context.Context, req *v1.McpServerCreateReq) (res *v1.McpServerCreateRes, err error)
+	McpServerList(ctx context.Context, req *v1.McpServerListReq) (res *v1.McpServerListRes, err error)
+	McpServerUpdate(ctx context.Context, req *v1.McpServerUpdateReq) (res *v1.McpServerUpdateRes, err error)
+	McpServerDelete(ctx context.Context, req *v1.McpServerDeleteReq) (res *v1.McpServerDeleteRes, err error)
+	WebhookToolCreate(ctx context.Context, req *v1.WebhookToolCreateReq) (res *v1.WebhookToolCreateRes, err error)
+	WebhookToolList(ctx context.Context, req *v1.WebhookToolListReq) (res *v1.WebhookToolListRes, err error)
+	WebhookToolUpdate(ctx context.Context, req *v1.WebhookToolUpdateReq) (res *v1.WebhookToolUpdateRes, err error)
+	WebhookToolDelete(ctx context.Context, req *v1.WebhookToolDeleteReq) (res *v1.WebhookToolDeleteRes, err error)
+	WebhookToolInvoke(ctx context.Context, req *v1.WebhookToolInvokeReq) (res *v1.WebhookToolInvokeRes, err error)
 }
@@ -1,6 +1,9 @@
 package v1
 
 import (
+	"flai/internal/logic"
+	"flai/internal/logic/keypool"
+	"flai/internal/logic/mcp"
 	"flai/internal/model/entity"
 
 	"github.com/gogf/gf/v2/frame/g"
@@ -25,6 +28,78 @@ type ProviderListReq struct {
 
 type ProviderListRes []*entity.Provider
 
+type ProviderKeyCreateReq struct {
+	g.Meta   `path:"/provider/{id}/key" method:"post" tag:"Provider(Admin)" summary:"Add an API key to the pool of a provider"`
+	Id       string `v:"required"`
+	Name     string `json:"name"`
+	ApiKey   string `json:"api_key" v:"required"`
+	IsActive bool   `json:"is_active" d:"true"`
+}
+
+type ProviderKeyCreateRes struct {
+	Id string `json:"id"`
+}
+
+type ProviderKeyListReq struct {
+	g.Meta `path:"/provider/{id}/key" method:"get" tag:"Provider(Admin)" summary:"List the API keys of a provider with their usage and health"`
+	Id     string `v:"required"`
+}
+
+type ProviderKeyListRes []*ProviderKeyResponse
+
+// ProviderKeyResponse is a pool key with its api key masked.
+type ProviderKeyResponse struct {
+	*entity.ProviderKey
+	keypool.Health
+}
+
+type ProviderKeyDeleteReq struct {
+	g.Meta `path:"/provider/{id}/key/{keyId}" method:"delete" tag:"Provider(Admin)" summary:"Remove an API key from the pool of a provider"`
+	Id     string `v:"required"`
+	KeyId  string `v:"required"`
+}
+
+type ProviderKeyDeleteRes struct{}
+
+type ModelAliasParams struct {
+	Name        string               `json:"name" v:"required|regex:^[a-zA-Z0-9._-]{1,64}$" dc:"Sent by clients as model_name with provider_id alias"`
+	DisplayName string               `json:"display_name"`
+	Description string               `json:"description"`
+	Strategy    string               `json:"strategy" d:"weighted" v:"in:weighted,cheapest" dc:"weighted picks targets at random by weight, cheapest the one with the lowest token price"`
+	Targets     []*logic.AliasTarget `json:"targets" v:"required"`
+	IsActive    bool                 `json:"is_active" d:"true"`
+}
+
+type ModelAliasCreateReq struct {
+	g.Meta `path:"/model-alias" method:"post" tag:"Provider(Admin)" summary:"Define a model alias"`
+	ModelAliasParams
+}
+
+type ModelAliasCreateRes struct {
+	Id string `json:"id"`
+}
+
+type ModelAliasListReq struct {
+	g.Meta `path:"/model-alias" method:"get" tag:"Provider(Admin)" summary:"List model aliases"`
+}
+
+type ModelAliasListRes []*entity.ModelAlias
+
+type ModelAliasUpdateReq struct {
+	g.Meta `path:"/model-alias/{id}" method:"put" tag:"Provider(Admin)" summary:"Update a model alias"`
+	Id     string `v:"required"`
+	ModelAliasParams
+}
+
+type ModelAliasUpdateRes struct{}
+
+type ModelAliasDeleteReq struct {
+	g.Meta `path:"/model-alias/{id}" method:"delete" tag:"Provider(Admin)" summary:"Delete a model alias"`
+	Id     string `v:"required"`
+}
+
+type ModelAliasDeleteRes struct{}
+
 type UserCreateReq struct {
 	g.Meta   `path:"/user" method:"post" tag:"User" summary:"Create user"`
 	Email    string `json:"email" v:"required"`
@@ -50,3 +125,108 @@ type UserGetListReq struct {
 type UserGetListRes struct {
 	List []entity.User `json:"list"`
 }
+
+type McpServerParams struct {
+	Name         string            `json:"name" v:"required"`
+	Transport    string            `json:"transport" v:"required|in:stdio,http"`
+	Command      string            `json:"command" v:"required-if:transport,stdio" dc:"Executable started for the stdio transport"`
+	Args         []string          `json:"args"`
+	Env          map[string]string `json:"env"`
+	Url          string            `json:"url" v:"required-if:transport,http|url" dc:"Endpoint of the streamable HTTP transport"`
+	Headers      map[string]string `json:"headers"`
+	AllowedTools []string          `json:"allowed_tools" dc:"Tools exposed to users, all tools when empty"`
+	Timeout      int               `json:"timeout" d:"60" v:"min:1" dc:"Timeout of a tool call in seconds"`
+	IsActive     bool              `json:"is_active" d:"true"`
+}
+
+type McpServerCreateReq struct {
+	g.Meta `path:"/mcp" method:"post" tag:"MCP(Admin)" summary:"Register an MCP server"`
+	McpServerParams
+}
+
+type McpServerCreateRes struct {
+	Id     string     `json:"id"`
+	Status mcp.Status `json:"status"`
+}
+
+type McpServerListReq struct {
+	g.Meta `path:"/mcp" method:"get" tag:"MCP(Admin)" summary:"List MCP servers with their connection status"`
+}
+
+type McpServerListRes []*McpServerResponse
+
+type McpServerResponse struct {
+	*entity.McpServer
+	Status mcp.Status `json:"status"`
+}
+
+type McpServerUpdateReq struct {
+	g.Meta `path:"/mcp/{id}" method:"put" tag:"MCP(Admin)" summary:"Update an MCP server and reconnect it"`
+	Id     string `v:"required"`
+	McpServerParams
+}
+
+type McpServerUpdateRes struct {
+	Status mcp.Status `json:"status"`
+}
+
+type McpServerDeleteReq struct {
+	g.Meta `path:"/mcp/{id}" method:"delete" tag:"MCP(Admin)" summary:"Delete an MCP server"`
+	Id     string `v:"required"`
+}
+
+type McpServerDeleteRes struct{}
+
+type WebhookToolParams struct {
+	Name            string         `json:"name" v:"required|regex:^[a-zA-Z_][a-zA-Z0-9_-]{0,63}$" dc:"Tool name shown to the model"`
+	Description     string         `json:"description" v:"required"`
+	Parameters      map[string]any `json:"parameters" dc:"JSON schema of the arguments object"`
+	Url             string         `json:"url" v:"required|url"`
+	Method          string         `json:"method" d:"POST" v:"in:GET,POST,PUT,PATCH"`
+	AuthHeader      string         `json:"auth_header" dc:"Header carrying the credentials, e.g. Authorization"`
+	AuthValue       string         `json:"auth_value"`
+	Timeout         int            `json:"timeout" d:"30" v:"min:1" dc:"Timeout of a call in seconds"`
+	MaxResponseSize int            `json:"max_response_size" d:"65536" v:"min:1" dc:"Longer responses are truncated"`
+	IsActive        bool           `json:"is_active" d:"true"`
+}
+
+type WebhookToolCreateReq struct {
+	g.Meta `path:"/webhook-tool" method:"post" tag:"Tool(Admin)" summary:"Declare an HTTP webhook tool"`
+	WebhookToolParams
+}
+
+type WebhookToolCreateRes struct {
+	Id string `json:"id"`
+}
+
+type WebhookToolListReq struct {
+	g.Meta `path:"/webhook-tool" method:"get" tag:"Tool(Admin)" summary:"List webhook tools"`
+}
+
+type WebhookToolListRes []*entity.WebhookTool
+
+type WebhookToolUpdateReq struct {
+	g.Meta `path:"/webhook-tool/{id}" method:"put" tag:"Tool(Admin)" summary:"Update a webhook tool"`
+	Id     string `v:"required"`
+	WebhookToolParams
+}
+
+type WebhookToolUpdateRes struct{}
+
+type WebhookToolDeleteReq struct {
+	g.Meta `path:"/webhook-tool/{id}" method:"delete" tag:"Tool(Admin)" summary:"Delete a webhook tool"`
+	Id     string `v:"required"`
+}
+
+type WebhookToolDeleteRes struct{}
+
+type WebhookToolInvokeReq struct {
+	g.Meta    `path:"/webhook-tool/{id}/invoke" method:"post" tag:"Tool(Admin)" summary:"Call a webhook tool with sample arguments"`
+	Id        string         `v:"required"`
+	Arguments map[string]any `json:"arguments"`
+}
+
+type WebhookToolInvokeRes struct {
+	Result  string `json:"result"`
+	IsError bool   `json:"is_error"`
+}
@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"flai/internal/model"
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type AssistantResponse struct {
+	Id      string `json:"id"`
+	UserId  string `json:"user_id"`
+	TeamId  string `json:"team_id"`
+	Version int    `json:"version"`
+	model.AssistantDefinition
+	CreatedAt *gtime.Time `json:"created_at"`
+	UpdatedAt *gtime.Time `json:"updated_at"`
+}
+
+type CreateReq struct {
+	g.Meta `path:"/assistant" method:"post" tag:"Assistant" summary:"Create an assistant"`
+	TeamId string `json:"team_id" dc:"Team the assistant is shared with, personal when empty"`
+	model.AssistantDefinition
+}
+
+type CreateRes struct {
+	*AssistantResponse
+}
+
+type GetListReq struct {
+	g.Meta `path:"/assistant" method:"get" tag:"Assistant" summary:"List assistants of the logined user and their teams"`
+}
+
+type GetListRes []*AssistantResponse
+
+type DetailReq struct {
+	g.Meta  `path:"/assistant/{id}" method:"get" tag:"Assistant" summary:"Get an assistant"`
+	Id      string `v:"required"`
+	Version int    `json:"version" dc:"Version to return, defaults to the current version"`
+}
+
+type DetailRes struct {
+	*AssistantResponse
+}
+
+type UpdateReq struct {
+	g.Meta `path:"/assistant/{id}" method:"put" tag:"Assistant" summary:"Update an assistant, creating a new version"`
+	Id     string `v:"required"`
+	model.AssistantDefinition
+}
+
+type UpdateRes struct {
+	*AssistantResponse
+}
+
+type DeleteReq struct {
+	g.Meta `path:"/assistant/{id}" method:"delete" tag:"Assistant" summary:"Delete an assistant"`
+	Id     string `v:"required"`
+}
+
+type DeleteRes struct{}
+
+type StartConversationReq struct {
+	g.Meta `path:"/assistant/{id}/conversation" method:"post" tag:"Assistant" summary:"Start a conversation with the current version of an assistant"`
+	Id     string `v:"required"`
+}
+
+type StartConversationRes struct {
+	entity.Conversation
+}
@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type UploadReq struct {
+	g.Meta `path:"/attachment" method:"post" mime:"multipart/form-data" tag:"Attachment" summary:"Upload an attachment for a message"`
+	File   *ghttp.UploadFile `json:"file" type:"file" v:"required"`
+}
+
+type UploadRes struct {
+	Id       string `json:"id"`
+	FileName string `json:"file_name"`
+	MimeType string `json:"mime_type"`
+	Size     int64  `json:"size"`
+}
+
+type DownloadReq struct {
+	g.Meta `path:"/attachment/{id}" method:"get" tag:"Attachment" summary:"Download an attachment"`
+	Id     string `v:"required"`
+}
+
+type DownloadRes struct{}
+
+type SignedUrlReq struct {
+	g.Meta `path:"/attachment/{id}/url" method:"get" tag:"Attachment" summary:"Get a time-limited download url for an attachment"`
+	Id     string `v:"required"`
+}
+
+type SignedUrlRes struct {
+	Url       string      `json:"url"`
+	ExpiresAt *gtime.Time `json:"expires_at"`
+}
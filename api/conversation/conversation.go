@@ -16,4 +16,10 @@ type IConversationV1 interface {
 	GetList(ctx context.Context, req *v1.GetListReq) (res *v1.GetListRes, err error)
 	Detail(ctx context.Context, req *v1.DetailReq) (res *v1.DetailRes, err error)
 	GenerateTitle(ctx context.Context, req *v1.GenerateTitleReq) (res *v1.GenerateTitleRes, err error)
+	Export(ctx context.Context, req *v1.ExportReq) (res *v1.ExportRes, err error)
+	ExportAll(ctx context.Context, req *v1.ExportAllReq) (res *v1.ExportAllRes, err error)
+	Import(ctx context.Context, req *v1.ImportReq) (res *v1.ImportRes, err error)
+	Fork(ctx context.Context, req *v1.ForkReq) (res *v1.ForkRes, err error)
+	GetSettings(ctx context.Context, req *v1.GetSettingsReq) (res *v1.GetSettingsRes, err error)
+	UpdateSettings(ctx context.Context, req *v1.UpdateSettingsReq) (res *v1.UpdateSettingsRes, err error)
 }
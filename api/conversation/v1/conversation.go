@@ -1,17 +1,21 @@
 package v1
 
 import (
+	"flai/internal/logic/importer"
 	"flai/internal/logic/llm"
+	"flai/internal/model"
 	"flai/internal/model/entity"
 	"flai/utility"
 
 	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
 	"github.com/gogf/gf/v2/os/gtime"
 )
 
 // Create
 type CreateReq struct {
-	g.Meta `path:"/conversation" method:"post" tag:"Conversation" Summary:"Create a new conversation"`
+	g.Meta   `path:"/conversation" method:"post" tag:"Conversation" Summary:"Create a new conversation"`
+	Settings *model.ChatSettings `json:"settings" dc:"Generation settings, provider defaults are used when empty"`
 }
 
 type CreateRes struct {
@@ -61,4 +65,65 @@ type GenerateTitleRes struct {
 	Icon  string `json:"icon"`
 }
 
+type ExportReq struct {
+	g.Meta    `path:"/conversation/{id}/export" method:"get" tag:"Conversation" Summary:"Export a conversation as markdown, json or html"`
+	Id        string `v:"required"`
+	Format    string `json:"format" d:"markdown" v:"in:markdown,json,html"`
+	MessageId string `json:"message_id" dc:"Leaf message of the branch to export, defaults to the latest message"`
+}
+
+type ExportRes struct{}
+
+type ExportAllReq struct {
+	g.Meta `path:"/conversation/export" method:"get" tag:"Conversation" Summary:"Export all conversations of the logined user as a zip archive"`
+	Format string `json:"format" d:"json" v:"in:markdown,json,html"`
+}
+
+type ExportAllRes struct{}
+
+type ImportReq struct {
+	g.Meta `path:"/conversation/import" method:"post" mime:"multipart/form-data" tag:"Conversation" Summary:"Import conversations from a ChatGPT or flai export"`
+	File   *ghttp.UploadFile `json:"file" type:"file" v:"required"`
+}
+
+type ImportRes struct {
+	Conversations []*ImportedConversation `json:"conversations"`
+	Skipped       []*importer.Skipped     `json:"skipped"`
+}
+
+type ImportedConversation struct {
+	Id           string `json:"id"`
+	Title        string `json:"title"`
+	MessageCount int    `json:"message_count"`
+}
+
+type ForkReq struct {
+	g.Meta    `path:"/conversation/{id}/fork" method:"post" tag:"Conversation" Summary:"Fork a conversation from a message into a new conversation"`
+	Id        string `v:"required"`
+	MessageId string `json:"message_id" v:"required" dc:"Last message to copy, its ancestors are copied along"`
+}
+
+type ForkRes struct {
+	entity.Conversation
+}
+
+type GetSettingsReq struct {
+	g.Meta `path:"/conversation/{id}/settings" method:"get" tag:"Conversation" Summary:"Get the generation settings of a conversation"`
+	Id     string `v:"required"`
+}
+
+type GetSettingsRes struct {
+	model.ChatSettings
+}
+
+type UpdateSettingsReq struct {
+	g.Meta `path:"/conversation/{id}/settings" method:"put" tag:"Conversation" Summary:"Replace the generation settings of a conversation"`
+	Id     string `v:"required"`
+	model.ChatSettings
+}
+
+type UpdateSettingsRes struct {
+	model.ChatSettings
+}
+
 // TODO: rename
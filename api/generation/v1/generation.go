@@ -0,0 +1,50 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type GenerationResponse struct {
+	Id              string      `json:"id"`
+	ConversationId  string      `json:"conversation_id"`
+	PromptMessageId string      `json:"prompt_message_id"`
+	MessageIds      []string    `json:"message_ids"`
+	Status          string      `json:"status"`
+	Error           string      `json:"error"`
+	Attachable      bool        `json:"attachable" dc:"Whether the events of the generation can still be streamed"`
+	CreatedAt       *gtime.Time `json:"created_at"`
+	FinishedAt      *gtime.Time `json:"finished_at"`
+}
+
+type GetListReq struct {
+	g.Meta         `path:"/generations" method:"get" tag:"Generation" summary:"List generations of a conversation"`
+	ConversationId string `json:"conversation_id" v:"required"`
+	Status         string `json:"status" v:"in:running,completed,failed,canceled" dc:"Only list generations with the status"`
+}
+
+type GetListRes []*GenerationResponse
+
+type DetailReq struct {
+	g.Meta `path:"/generations/{id}" method:"get" tag:"Generation" summary:"Get a generation"`
+	Id     string `v:"required"`
+}
+
+type DetailRes struct {
+	*GenerationResponse
+}
+
+type StreamReq struct {
+	g.Meta `path:"/generations/{id}/stream" method:"get" tag:"Generation" summary:"Attach to the events of a generation"`
+	Id     string `v:"required"`
+	From   *int   `json:"from" dc:"Index of the first event to stream, defaults to the one after the Last-Event-ID header or to the start"`
+}
+
+type StreamRes struct{}
+
+type CancelReq struct {
+	g.Meta `path:"/generations/{id}/cancel" method:"post" tag:"Generation" summary:"Stop a generation, keeping what was generated"`
+	Id     string `v:"required"`
+}
+
+type CancelRes struct{}
@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+)
+
+type CreateReq struct {
+	g.Meta         `path:"/knowledge" method:"post" tag:"Knowledge" summary:"Create a knowledge base"`
+	Name           string `json:"name" v:"required|max-length:100"`
+	Description    string `json:"description"`
+	TeamId         string `json:"team_id" dc:"Team owning the knowledge base, personal when empty"`
+	ProviderId     string `json:"provider_id" v:"required" dc:"Provider used to embed the documents"`
+	EmbeddingModel string `json:"embedding_model" v:"required"`
+}
+
+type CreateRes struct {
+	Id string `json:"id"`
+}
+
+type GetListReq struct {
+	g.Meta `path:"/knowledge" method:"get" tag:"Knowledge" summary:"List knowledge bases of the logined user and their teams"`
+}
+
+type GetListRes []*entity.KnowledgeBase
+
+type DetailReq struct {
+	g.Meta `path:"/knowledge/{id}" method:"get" tag:"Knowledge" summary:"Get a knowledge base with its documents"`
+	Id     string `v:"required"`
+}
+
+type DetailRes struct {
+	*entity.KnowledgeBase
+	Documents []*entity.KnowledgeDocument `json:"documents"`
+}
+
+type DeleteReq struct {
+	g.Meta `path:"/knowledge/{id}" method:"delete" tag:"Knowledge" summary:"Delete a knowledge base"`
+	Id     string `v:"required"`
+}
+
+type DeleteRes struct{}
+
+type UploadReq struct {
+	g.Meta `path:"/knowledge/{id}/document" method:"post" mime:"multipart/form-data" tag:"Knowledge" summary:"Add a document to a knowledge base"`
+	Id     string            `v:"required"`
+	File   *ghttp.UploadFile `json:"file" type:"file" v:"required"`
+}
+
+type UploadRes struct {
+	*entity.KnowledgeDocument
+}
+
+type DeleteDocumentReq struct {
+	g.Meta     `path:"/knowledge/{id}/document/{document_id}" method:"delete" tag:"Knowledge" summary:"Remove a document from a knowledge base"`
+	Id         string `v:"required"`
+	DocumentId string `json:"document_id" v:"required"`
+}
+
+type DeleteDocumentRes struct{}
@@ -12,6 +12,7 @@ import (
 
 type IMessageV1 interface {
 	Create(ctx context.Context, req *v1.CreateReq) (res *v1.CreateRes, err error)
+	Compare(ctx context.Context, req *v1.CompareReq) (res *v1.CompareRes, err error)
 	Retry(ctx context.Context, req *v1.RetryReq) (res *v1.RetryRes, err error)
 	Edit(ctx context.Context, req *v1.EditReq) (res *v1.EditRes, err error)
 	Delete(ctx context.Context, req *v1.DeleteReq) (res *v1.DeleteRes, err error)
@@ -1,23 +1,50 @@
 package v1
 
 import (
+	"flai/internal/model"
+
 	"github.com/gogf/gf/v2/frame/g"
 )
 
 type CreateReq struct {
 	g.Meta         `path:"/messages" method:"post" tag:"" summary:"Create message"`
-	Id             string   `json:"id" v:"required"`
-	ConversationId string   `json:"conversation_id" v:"required"`
-	ProviderId     string   `json:"provider_id" v:"required"`
-	ModelName      string   `json:"model_name" v:"required"`
-	MessagePath    []string `json:"message_path"`
-	Prompt         string   `json:"prompt" v:"required"`
-	Tools          []string `json:"tools"`
+	Id             string              `json:"id" v:"required"`
+	ConversationId string              `json:"conversation_id" v:"required"`
+	ProviderId     string              `json:"provider_id" dc:"Defaults to the model of the assistant the conversation was started from"`
+	ModelName      string              `json:"model_name"`
+	MessagePath    []string            `json:"message_path"`
+	Prompt         string              `json:"prompt"`
+	Attachments    []string            `json:"attachments" dc:"Ids of uploaded attachments"`
+	KnowledgeBases []string            `json:"knowledge_bases" dc:"Ids of knowledge bases to retrieve context from, defaults to those of the assistant"`
+	Tools          []string            `json:"tools" dc:"Defaults to the tools of the assistant"`
+	Settings       *model.ChatSettings `json:"settings" dc:"Overrides the generation settings of the conversation for this request"`
 }
 
 type CreateRes struct {
 }
 
+// CompareModel is one of the models answering a prompt side by side.
+type CompareModel struct {
+	ProviderId string `json:"provider_id" v:"required"`
+	ModelName  string `json:"model_name" v:"required"`
+}
+
+type CompareReq struct {
+	g.Meta         `path:"/messages/compare" method:"post" tag:"" summary:"Answer a message with several models side by side"`
+	Id             string              `json:"id" v:"required"`
+	ConversationId string              `json:"conversation_id" v:"required"`
+	Models         []*CompareModel     `json:"models" v:"required" dc:"Two to four models, each answer is stored as a sibling message"`
+	MessagePath    []string            `json:"message_path"`
+	Prompt         string              `json:"prompt"`
+	Attachments    []string            `json:"attachments" dc:"Ids of uploaded attachments"`
+	KnowledgeBases []string            `json:"knowledge_bases" dc:"Ids of knowledge bases to retrieve context from, defaults to those of the assistant"`
+	Tools          []string            `json:"tools" dc:"Defaults to the tools of the assistant"`
+	Settings       *model.ChatSettings `json:"settings" dc:"Overrides the generation settings of the conversation for this request"`
+}
+
+type CompareRes struct {
+}
+
 type RetryReq struct {
 	g.Meta         `path:"/messages/retry" method:"post" tag:"" summary:"Retry message"`
 	ConversationId string   `json:"conversation_id" v:"required"`
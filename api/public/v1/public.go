@@ -0,0 +1,31 @@
+package v1
+
+import (
+	"flai/internal/logic/export"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type ShareViewReq struct {
+	g.Meta `path:"/share/{id}" method:"get" tag:"Public" summary:"View a shared conversation thread"`
+	Id     string `v:"required"`
+}
+
+type ShareViewRes struct {
+	Id        string                  `json:"id"`
+	Title     string                  `json:"title"`
+	Icon      string                  `json:"icon"`
+	Messages  []*export.ExportMessage `json:"messages"`
+	CreatedAt *gtime.Time             `json:"created_at"`
+	ExpiresAt *gtime.Time             `json:"expires_at"`
+}
+
+type AttachmentReq struct {
+	g.Meta    `path:"/attachment/{id}" method:"get" tag:"Public" summary:"Download an attachment through a signed url"`
+	Id        string `v:"required"`
+	Expires   string `json:"expires" v:"required"`
+	Signature string `json:"signature" v:"required"`
+}
+
+type AttachmentRes struct{}
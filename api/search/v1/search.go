@@ -0,0 +1,16 @@
+package v1
+
+import (
+	"flai/internal/model"
+	"flai/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+type SearchReq struct {
+	g.Meta `path:"/search" method:"get" tag:"Search" summary:"Full-text search across conversation history"`
+	Q      string `json:"q" v:"required"`
+	utility.PageReq
+}
+
+type SearchRes utility.PageRes[model.SearchHit]
@@ -0,0 +1,52 @@
+package v1
+
+import (
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type CreateReq struct {
+	g.Meta         `path:"/share" method:"post" tag:"Share" summary:"Create a share link for a conversation branch"`
+	ConversationId string      `json:"conversation_id" v:"required"`
+	MessageId      string      `json:"message_id" v:"required" dc:"Last message of the shared branch"`
+	ExpiresAt      *gtime.Time `json:"expires_at" dc:"Optional expiry, the link never expires when empty"`
+}
+
+type CreateRes struct {
+	Id string `json:"id"`
+}
+
+type GetListReq struct {
+	g.Meta `path:"/share" method:"get" tag:"Share" summary:"List share links of the logined user"`
+}
+
+type GetListRes []*ShareResponse
+
+type ShareResponse struct {
+	Id             string      `json:"id"`
+	ConversationId string      `json:"conversation_id"`
+	MessageId      string      `json:"message_id"`
+	Title          string      `json:"title"`
+	Icon           string      `json:"icon"`
+	ExpiresAt      *gtime.Time `json:"expires_at"`
+	RevokedAt      *gtime.Time `json:"revoked_at"`
+	CreatedAt      *gtime.Time `json:"created_at"`
+}
+
+type RevokeReq struct {
+	g.Meta `path:"/share/{id}" method:"delete" tag:"Share" summary:"Revoke a share link"`
+	Id     string `v:"required"`
+}
+
+type RevokeRes struct{}
+
+type ForkReq struct {
+	g.Meta `path:"/share/{id}/fork" method:"post" tag:"Share" summary:"Fork a shared thread into a new conversation"`
+	Id     string `v:"required"`
+}
+
+type ForkRes struct {
+	entity.Conversation
+}
@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type CreateReq struct {
+	g.Meta `path:"/team" method:"post" tag:"Team" summary:"Create a team owned by the logined user"`
+	Name   string `json:"name" v:"required|max-length:100"`
+}
+
+type CreateRes struct {
+	Id string `json:"id"`
+}
+
+type GetListReq struct {
+	g.Meta `path:"/team" method:"get" tag:"Team" summary:"List teams of the logined user"`
+}
+
+type GetListRes []*TeamResponse
+
+type TeamResponse struct {
+	Id        string      `json:"id"`
+	Name      string      `json:"name"`
+	OwnerId   string      `json:"owner_id"`
+	Role      string      `json:"role"`
+	CreatedAt *gtime.Time `json:"created_at"`
+}
+
+type DeleteReq struct {
+	g.Meta `path:"/team/{id}" method:"delete" tag:"Team" summary:"Delete a team and its knowledge bases"`
+	Id     string `v:"required"`
+}
+
+type DeleteRes struct{}
+
+type GetMembersReq struct {
+	g.Meta `path:"/team/{id}/member" method:"get" tag:"Team" summary:"List members of a team"`
+	Id     string `v:"required"`
+}
+
+type GetMembersRes []*MemberResponse
+
+type MemberResponse struct {
+	UserId    string      `json:"user_id"`
+	Username  string      `json:"username"`
+	Email     string      `json:"email"`
+	Avatar    string      `json:"avatar"`
+	Role      string      `json:"role"`
+	CreatedAt *gtime.Time `json:"created_at"`
+}
+
+type AddMemberReq struct {
+	g.Meta `path:"/team/{id}/member" method:"post" tag:"Team" summary:"Add a user to a team by email"`
+	Id     string `v:"required"`
+	Email  string `json:"email" v:"required|email"`
+}
+
+type AddMemberRes struct{}
+
+type RemoveMemberReq struct {
+	g.Meta `path:"/team/{id}/member/{user_id}" method:"delete" tag:"Team" summary:"Remove a member from a team, members may remove themselves"`
+	Id     string `v:"required"`
+	UserId string `json:"user_id" v:"required"`
+}
+
+type RemoveMemberRes struct{}
@@ -0,0 +1,17 @@
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+type ListReq struct {
+	g.Meta `path:"/tool" method:"get" tag:"Tool" summary:"List tools that can be enabled for a message"`
+}
+
+type ListRes []*ToolResponse
+
+type ToolResponse struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Parameters  map[string]any `json:"parameters,omitempty"`
+}
@@ -3,21 +3,39 @@ package cmd
 import (
 	"context"
 	"flai/internal/controller/admin"
+	"flai/internal/controller/assistant"
+	"flai/internal/controller/attachment"
 	"flai/internal/controller/auth"
 	"flai/internal/controller/conversation"
+	"flai/internal/controller/generation"
+	"flai/internal/controller/knowledge"
 	"flai/internal/controller/message"
 	"flai/internal/controller/provider"
+	"flai/internal/controller/public"
+	"flai/internal/controller/search"
+	"flai/internal/controller/share"
+	"flai/internal/controller/team"
+	"flai/internal/controller/tool"
 	"flai/internal/controller/user"
 	"flai/internal/logic"
+	logicAttachment "flai/internal/logic/attachment"
+	logicGeneration "flai/internal/logic/generation"
+	"flai/internal/logic/keypool"
+	"flai/internal/logic/mcp"
+	"flai/internal/logic/sandbox"
+	"flai/internal/logic/webhook"
+	"flai/internal/logic/websearch"
 	"flai/internal/middleware"
 	"flai/utility"
 	"net/http"
 	"strings"
+	"time"
 
 	_ "github.com/gogf/gf/contrib/drivers/pgsql/v2"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
 	"github.com/gogf/gf/v2/os/gcmd"
+	"github.com/gogf/gf/v2/os/gtimer"
 )
 
 var (
@@ -29,6 +47,18 @@ var (
 			utility.InitTokenManager(ctx)
 			logic.UpdateProviderCache(ctx)
 			logic.UpdateSystemConfigCache(ctx)
+			if err := logic.UpdateModelAliasCache(ctx); err != nil {
+				g.Log().Fatal(ctx, err)
+			}
+			keypool.Load(ctx)
+			logicGeneration.MarkInterrupted(ctx)
+			mcp.Start(ctx)
+			webhook.Start(ctx)
+			websearch.Register(ctx)
+			sandbox.Register(ctx)
+			gtimer.AddSingleton(ctx, time.Hour, func(ctx context.Context) {
+				logicAttachment.PurgeOrphans(ctx, 24*time.Hour)
+			})
 
 			s := g.Server()
 			RegisterRouter(s)
@@ -36,16 +66,41 @@ var (
 			return nil
 		},
 	}
+
+	// Sandbox is the worker process the code interpreter starts for every
+	// script.
+	Sandbox = gcmd.Command{
+		Name:  sandbox.WorkerCommand,
+		Usage: sandbox.WorkerCommand,
+		Brief: "run one code interpreter script read from stdin",
+		Func: func(ctx context.Context, parser *gcmd.Parser) error {
+			return sandbox.Serve(ctx)
+		},
+	}
 )
 
+func init() {
+	if err := Main.AddCommand(&Sandbox); err != nil {
+		panic(err)
+	}
+}
+
 func RegisterRouter(s *ghttp.Server) {
 	s.Group("/api", func(group *ghttp.RouterGroup) {
 		group.Middleware(middleware.RequireAuth)
 		group.Middleware(ghttp.MiddlewareHandlerResponse)
 		group.Bind(
+			assistant.NewV1(),
+			attachment.NewV1(),
 			conversation.NewV1(),
+			generation.NewV1(),
+			knowledge.NewV1(),
 			message.NewV1(),
 			provider.NewV1(),
+			search.NewV1(),
+			share.NewV1(),
+			team.NewV1(),
+			tool.NewV1(),
 			user.NewV1(),
 		)
 	})
@@ -55,6 +110,12 @@ func RegisterRouter(s *ghttp.Server) {
 			auth.NewV1(),
 		)
 	})
+	s.Group("/public", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareHandlerResponse)
+		group.Bind(
+			public.NewV1(),
+		)
+	})
 	s.Group("/admin", func(group *ghttp.RouterGroup) {
 		group.Middleware(middleware.RequireAuth)
 		group.Middleware(middleware.RequireAdminAuth)
@@ -70,7 +131,8 @@ func RegisterRouter(s *ghttp.Server) {
 	s.BindStatusHandler(http.StatusNotFound, func(r *ghttp.Request) {
 		if strings.HasPrefix(r.Request.URL.Path, "/api") ||
 			strings.HasPrefix(r.Request.URL.Path, "/auth") ||
-			strings.HasPrefix(r.Request.URL.Path, "/admin") {
+			strings.HasPrefix(r.Request.URL.Path, "/admin") ||
+			strings.HasPrefix(r.Request.URL.Path, "/public") {
 			r.Response.WriteStatus(http.StatusNotFound)
 			return
 		}
@@ -8,19 +8,34 @@ var (
 
 // Message types
 var MessageType = struct {
-	Message             string
-	Reasoning           string
-	Image               string
-	FunctionCall        string
-	MetaInfo            string
-	GoogleGroundingData string
+	Message      string
+	Reasoning    string
+	Image        string
+	FunctionCall string
+	MetaInfo     string
+	Attachment   string
+	Citation     string
+	Done         string
+	Error        string
 }{
-	Message:             "message",
-	Reasoning:           "reasoning",
-	Image:               "image",
-	FunctionCall:        "function_call",
-	MetaInfo:            "meta_info",
-	GoogleGroundingData: "google_grounding_data",
+	Message:      "message",
+	Reasoning:    "reasoning",
+	Image:        "image",
+	FunctionCall: "function_call",
+	MetaInfo:     "meta_info",
+	Attachment:   "attachment",
+	Citation:     "citation",
+	Done:         "done",
+	Error:        "error",
+}
+
+// Citation sources
+var CitationSource = struct {
+	KnowledgeBase string
+	Web           string
+}{
+	KnowledgeBase: "knowledge_base",
+	Web:           "web",
 }
 
 // User roles
@@ -55,13 +70,108 @@ var ProviderType = struct {
 // System config keys
 var SystemConfig = struct {
 	TitleGeneration string
+	ModelFallback   string
 }{
 	TitleGeneration: "title_generation",
+	ModelFallback:   "model_fallback",
 }
 
 // Internal tools
 var InternalTools = struct {
-	InternalWebSearch string
+	InternalWebSearch       string
+	InternalImageGeneration string
+	WebSearch               string
+	FetchPage               string
+	RunCode                 string
+}{
+	InternalWebSearch:       "internal_web_search",
+	InternalImageGeneration: "internal_image_generation",
+	WebSearch:               "web_search",
+	FetchPage:               "fetch_page",
+	RunCode:                 "run_code",
+}
+
+// Conversation export formats
+var ExportFormat = struct {
+	Markdown string
+	JSON     string
+	HTML     string
+}{
+	Markdown: "markdown",
+	JSON:     "json",
+	HTML:     "html",
+}
+
+// MCP server transports
+var McpTransport = struct {
+	Stdio string
+	Http  string
+}{
+	Stdio: "stdio",
+	Http:  "http",
+}
+
+// Team member roles
+var TeamRole = struct {
+	Owner  string
+	Member string
+}{
+	Owner:  "owner",
+	Member: "member",
+}
+
+// Reasoning effort levels
+var ReasoningEffort = struct {
+	Minimal string
+	Low     string
+	Medium  string
+	High    string
+}{
+	Minimal: "minimal",
+	Low:     "low",
+	Medium:  "medium",
+	High:    "high",
+}
+
+// AliasProviderId is the provider id under which model aliases are listed
+// and requested.
+const AliasProviderId = "alias"
+
+// Routing strategies of model aliases
+var AliasStrategy = struct {
+	Weighted string
+	Cheapest string
+}{
+	Weighted: "weighted",
+	Cheapest: "cheapest",
+}
+
+// Generation job statuses
+var GenerationStatus = struct {
+	Running   string
+	Completed string
+	Failed    string
+	Canceled  string
+}{
+	Running:   "running",
+	Completed: "completed",
+	Failed:    "failed",
+	Canceled:  "canceled",
+}
+
+// Model input modalities
+var Modality = struct {
+	Text  string
+	Image string
+	Audio string
+	Video string
+	PDF   string
+	File  string
 }{
-	InternalWebSearch: "internal_web_search",
+	Text:  "text",
+	Image: "image",
+	Audio: "audio",
+	Video: "video",
+	PDF:   "pdf",
+	File:  "file",
 }
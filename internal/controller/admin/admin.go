@@ -3,3 +3,14 @@
 // =================================================================================
 
 package admin
+
+import "encoding/json"
+
+// jsonColumn encodes a value for a jsonb column, using empty for nil values.
+func jsonColumn(value any, empty string) string {
+	data, err := json.Marshal(value)
+	if err != nil || string(data) == "null" {
+		return empty
+	}
+	return string(data)
+}
@@ -0,0 +1,33 @@
+package admin
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic/mcp"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/google/uuid"
+
+	"flai/api/admin/v1"
+)
+
+func (c *ControllerV1) McpServerCreate(ctx context.Context, req *v1.McpServerCreateReq) (res *v1.McpServerCreateRes, err error) {
+	id := uuid.New().String()
+	data := mcpServerData(&req.McpServerParams)
+	data.Id = id
+	_, err = dao.McpServer.Ctx(ctx).Data(data).Insert()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to create MCP server")
+	}
+
+	// A server that cannot be reached is still saved, the status shows why
+	if err = mcp.Reload(ctx, id); err != nil {
+		g.Log().Warningf(ctx, "Failed to connect MCP server %s: %v", req.Name, err)
+	}
+	return &v1.McpServerCreateRes{
+		Id:     id,
+		Status: mcp.GetStatus(id),
+	}, nil
+}
@@ -0,0 +1,27 @@
+package admin
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic/mcp"
+	"flai/internal/model/do"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/admin/v1"
+)
+
+func (c *ControllerV1) McpServerDelete(ctx context.Context, req *v1.McpServerDeleteReq) (res *v1.McpServerDeleteRes, err error) {
+	result, err := dao.McpServer.Ctx(ctx).Where(do.McpServer{
+		Id: req.Id,
+	}).Delete()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to delete MCP server")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "MCP server not found")
+	}
+	mcp.Stop(req.Id)
+	return &v1.McpServerDeleteRes{}, nil
+}
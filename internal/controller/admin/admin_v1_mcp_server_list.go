@@ -0,0 +1,30 @@
+package admin
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic/mcp"
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/admin/v1"
+)
+
+func (c *ControllerV1) McpServerList(ctx context.Context, req *v1.McpServerListReq) (res *v1.McpServerListRes, err error) {
+	var servers []*entity.McpServer
+	err = dao.McpServer.Ctx(ctx).OrderAsc("name").Scan(&servers)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch MCP servers")
+	}
+
+	res = &v1.McpServerListRes{}
+	for _, server := range servers {
+		*res = append(*res, &v1.McpServerResponse{
+			McpServer: server,
+			Status:    mcp.GetStatus(server.Id),
+		})
+	}
+	return res, nil
+}
@@ -0,0 +1,56 @@
+package admin
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic/mcp"
+	"flai/internal/model/do"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"flai/api/admin/v1"
+)
+
+func (c *ControllerV1) McpServerUpdate(ctx context.Context, req *v1.McpServerUpdateReq) (res *v1.McpServerUpdateRes, err error) {
+	data := mcpServerData(&req.McpServerParams)
+	data.UpdatedAt = gtime.Now()
+	result, err := dao.McpServer.Ctx(ctx).Data(data).Where(do.McpServer{
+		Id: req.Id,
+	}).Update()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to update MCP server")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "MCP server not found")
+	}
+
+	if err = mcp.Reload(ctx, req.Id); err != nil {
+		g.Log().Warningf(ctx, "Failed to connect MCP server %s: %v", req.Name, err)
+	}
+	return &v1.McpServerUpdateRes{
+		Status: mcp.GetStatus(req.Id),
+	}, nil
+}
+
+// mcpServerData converts the request parameters into the stored columns.
+func mcpServerData(params *v1.McpServerParams) do.McpServer {
+	isActive := 0
+	if params.IsActive {
+		isActive = 1
+	}
+	return do.McpServer{
+		Name:         params.Name,
+		Transport:    params.Transport,
+		Command:      params.Command,
+		Args:         jsonColumn(params.Args, "[]"),
+		Env:          jsonColumn(params.Env, "{}"),
+		Url:          params.Url,
+		Headers:      jsonColumn(params.Headers, "{}"),
+		AllowedTools: jsonColumn(params.AllowedTools, "[]"),
+		Timeout:      params.Timeout,
+		IsActive:     isActive,
+	}
+}
@@ -0,0 +1,32 @@
+package admin
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/google/uuid"
+
+	"flai/api/admin/v1"
+)
+
+func (c *ControllerV1) ModelAliasCreate(ctx context.Context, req *v1.ModelAliasCreateReq) (res *v1.ModelAliasCreateRes, err error) {
+	id := uuid.New().String()
+	data, err := modelAliasData(ctx, &req.ModelAliasParams, id)
+	if err != nil {
+		return nil, err
+	}
+	data.Id = id
+	_, err = dao.ModelAlias.Ctx(ctx).Data(data).Insert()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to create model alias")
+	}
+	if err = logic.UpdateModelAliasCache(ctx); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to reload model aliases")
+	}
+	return &v1.ModelAliasCreateRes{
+		Id: id,
+	}, nil
+}
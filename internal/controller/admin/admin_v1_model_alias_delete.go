@@ -0,0 +1,29 @@
+package admin
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic"
+	"flai/internal/model/do"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/admin/v1"
+)
+
+func (c *ControllerV1) ModelAliasDelete(ctx context.Context, req *v1.ModelAliasDeleteReq) (res *v1.ModelAliasDeleteRes, err error) {
+	result, err := dao.ModelAlias.Ctx(ctx).Where(do.ModelAlias{
+		Id: req.Id,
+	}).Delete()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to delete model alias")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Model alias not found")
+	}
+	if err = logic.UpdateModelAliasCache(ctx); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to reload model aliases")
+	}
+	return &v1.ModelAliasDeleteRes{}, nil
+}
@@ -0,0 +1,80 @@
+package admin
+
+import (
+	"context"
+	"flai/internal/consts"
+	"flai/internal/dao"
+	"flai/internal/logic"
+	"flai/internal/model/do"
+	"slices"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"flai/api/admin/v1"
+)
+
+func (c *ControllerV1) ModelAliasUpdate(ctx context.Context, req *v1.ModelAliasUpdateReq) (res *v1.ModelAliasUpdateRes, err error) {
+	data, err := modelAliasData(ctx, &req.ModelAliasParams, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	data.UpdatedAt = gtime.Now()
+	result, err := dao.ModelAlias.Ctx(ctx).Data(data).Where(do.ModelAlias{
+		Id: req.Id,
+	}).Update()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to update model alias")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Model alias not found")
+	}
+	if err = logic.UpdateModelAliasCache(ctx); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to reload model aliases")
+	}
+	return &v1.ModelAliasUpdateRes{}, nil
+}
+
+// modelAliasData validates the request parameters of the alias with the id
+// and converts them into the stored columns.
+func modelAliasData(ctx context.Context, params *v1.ModelAliasParams, id string) (*do.ModelAlias, error) {
+	count, err := dao.ModelAlias.Ctx(ctx).Where(do.ModelAlias{Name: params.Name}).WhereNot("id", id).Count()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to check model alias name")
+	}
+	if count > 0 {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "Model alias name is already in use")
+	}
+	roles := []string{consts.UserRole.User, consts.UserRole.Admin}
+	for _, target := range params.Targets {
+		if target == nil {
+			return nil, gerror.NewCode(gcode.CodeInvalidParameter, "Invalid target")
+		}
+		providerInfo := logic.ProviderMap[target.ProviderId]
+		if providerInfo == nil || providerInfo.ModelIdMap[target.ModelName] == nil {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, "Unknown model %s of provider %s", target.ModelName, target.ProviderId)
+		}
+		if target.Weight < 0 {
+			return nil, gerror.NewCode(gcode.CodeInvalidParameter, "Target weight cannot be negative")
+		}
+		for _, role := range target.Roles {
+			if !slices.Contains(roles, role) {
+				return nil, gerror.NewCodef(gcode.CodeInvalidParameter, "Unknown role %s", role)
+			}
+		}
+	}
+
+	isActive := 0
+	if params.IsActive {
+		isActive = 1
+	}
+	return &do.ModelAlias{
+		Name:        params.Name,
+		DisplayName: params.DisplayName,
+		Description: params.Description,
+		Strategy:    params.Strategy,
+		Targets:     jsonColumn(params.Targets, "[]"),
+		IsActive:    isActive,
+	}, nil
+}
@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic"
+	"flai/internal/logic/keypool"
+	"flai/internal/model/entity"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/google/uuid"
+
+	"flai/api/admin/v1"
+)
+
+func (c *ControllerV1) ProviderKeyCreate(ctx context.Context, req *v1.ProviderKeyCreateReq) (res *v1.ProviderKeyCreateRes, err error) {
+	if logic.ProviderMap[req.Id] == nil {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Provider not found")
+	}
+	key := &entity.ProviderKey{
+		Id:         uuid.New().String(),
+		ProviderId: req.Id,
+		Name:       strings.TrimSpace(req.Name),
+		ApiKey:     strings.TrimSpace(req.ApiKey),
+	}
+	if req.IsActive {
+		key.IsActive = 1
+	}
+	_, err = dao.ProviderKey.Ctx(ctx).Data(key).Insert()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to create provider key")
+	}
+	if req.IsActive {
+		keypool.Add(key)
+	}
+	return &v1.ProviderKeyCreateRes{
+		Id: key.Id,
+	}, nil
+}
@@ -0,0 +1,28 @@
+package admin
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic/keypool"
+	"flai/internal/model/do"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/admin/v1"
+)
+
+func (c *ControllerV1) ProviderKeyDelete(ctx context.Context, req *v1.ProviderKeyDeleteReq) (res *v1.ProviderKeyDeleteRes, err error) {
+	result, err := dao.ProviderKey.Ctx(ctx).Where(do.ProviderKey{
+		Id:         req.KeyId,
+		ProviderId: req.Id,
+	}).Delete()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to delete provider key")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Provider key not found")
+	}
+	keypool.Remove(req.Id, req.KeyId)
+	return &v1.ProviderKeyDeleteRes{}, nil
+}
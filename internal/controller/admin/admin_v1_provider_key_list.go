@@ -0,0 +1,35 @@
+package admin
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic/keypool"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/admin/v1"
+)
+
+func (c *ControllerV1) ProviderKeyList(ctx context.Context, req *v1.ProviderKeyListReq) (res *v1.ProviderKeyListRes, err error) {
+	var keys []*entity.ProviderKey
+	err = dao.ProviderKey.Ctx(ctx).Where(do.ProviderKey{
+		ProviderId: req.Id,
+	}).OrderAsc("created_at").Scan(&keys)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch provider keys")
+	}
+
+	res = &v1.ProviderKeyListRes{}
+	for _, key := range keys {
+		health := keypool.GetHealth(req.Id, key.Id)
+		key.ApiKey = keypool.Mask(key.ApiKey)
+		*res = append(*res, &v1.ProviderKeyResponse{
+			ProviderKey: key,
+			Health:      health,
+		})
+	}
+	return res, nil
+}
@@ -0,0 +1,32 @@
+package admin
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic/webhook"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/google/uuid"
+
+	"flai/api/admin/v1"
+)
+
+func (c *ControllerV1) WebhookToolCreate(ctx context.Context, req *v1.WebhookToolCreateReq) (res *v1.WebhookToolCreateRes, err error) {
+	id := uuid.New().String()
+	if webhook.NameTaken(req.Name, id) {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "Tool name is already in use")
+	}
+	data := webhookToolData(&req.WebhookToolParams)
+	data.Id = id
+	_, err = dao.WebhookTool.Ctx(ctx).Data(data).Insert()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to create webhook tool")
+	}
+	if err = webhook.Reload(ctx, id); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to register webhook tool")
+	}
+	return &v1.WebhookToolCreateRes{
+		Id: id,
+	}, nil
+}
@@ -0,0 +1,27 @@
+package admin
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic/webhook"
+	"flai/internal/model/do"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/admin/v1"
+)
+
+func (c *ControllerV1) WebhookToolDelete(ctx context.Context, req *v1.WebhookToolDeleteReq) (res *v1.WebhookToolDeleteRes, err error) {
+	result, err := dao.WebhookTool.Ctx(ctx).Where(do.WebhookTool{
+		Id: req.Id,
+	}).Delete()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to delete webhook tool")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Webhook tool not found")
+	}
+	webhook.Remove(req.Id)
+	return &v1.WebhookToolDeleteRes{}, nil
+}
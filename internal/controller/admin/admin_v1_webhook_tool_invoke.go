@@ -0,0 +1,43 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"flai/internal/dao"
+	"flai/internal/logic/webhook"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+	"net/http"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/admin/v1"
+)
+
+func (c *ControllerV1) WebhookToolInvoke(ctx context.Context, req *v1.WebhookToolInvokeReq) (res *v1.WebhookToolInvokeRes, err error) {
+	var tool *entity.WebhookTool
+	err = dao.WebhookTool.Ctx(ctx).Where(do.WebhookTool{
+		Id: req.Id,
+	}).Scan(&tool)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch webhook tool")
+	}
+	if tool == nil {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Webhook tool not found")
+	}
+	arguments, err := json.Marshal(req.Arguments)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "Invalid arguments")
+	}
+
+	// Use the same timeout the chat applies to the tool
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(max(tool.Timeout, 1))*time.Second)
+	defer cancel()
+	result, err := webhook.Call(ctx, http.DefaultClient, tool, string(arguments))
+	if err != nil {
+		return &v1.WebhookToolInvokeRes{Result: err.Error(), IsError: true}, nil
+	}
+	return &v1.WebhookToolInvokeRes{Result: result}, nil
+}
@@ -0,0 +1,20 @@
+package admin
+
+import (
+	"context"
+	"flai/internal/dao"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/admin/v1"
+)
+
+func (c *ControllerV1) WebhookToolList(ctx context.Context, req *v1.WebhookToolListReq) (res *v1.WebhookToolListRes, err error) {
+	res = &v1.WebhookToolListRes{}
+	err = dao.WebhookTool.Ctx(ctx).OrderAsc("name").Scan(res)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch webhook tools")
+	}
+	return res, nil
+}
@@ -0,0 +1,59 @@
+package admin
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic/webhook"
+	"flai/internal/model/do"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"flai/api/admin/v1"
+)
+
+func (c *ControllerV1) WebhookToolUpdate(ctx context.Context, req *v1.WebhookToolUpdateReq) (res *v1.WebhookToolUpdateRes, err error) {
+	if webhook.NameTaken(req.Name, req.Id) {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "Tool name is already in use")
+	}
+	data := webhookToolData(&req.WebhookToolParams)
+	data.UpdatedAt = gtime.Now()
+	result, err := dao.WebhookTool.Ctx(ctx).Data(data).Where(do.WebhookTool{
+		Id: req.Id,
+	}).Update()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to update webhook tool")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Webhook tool not found")
+	}
+	if err = webhook.Reload(ctx, req.Id); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to register webhook tool")
+	}
+	return &v1.WebhookToolUpdateRes{}, nil
+}
+
+// webhookToolData converts the request parameters into the stored columns.
+func webhookToolData(params *v1.WebhookToolParams) do.WebhookTool {
+	isActive := 0
+	if params.IsActive {
+		isActive = 1
+	}
+	parameters := params.Parameters
+	if parameters == nil {
+		parameters = map[string]any{"type": "object"}
+	}
+	return do.WebhookTool{
+		Name:            params.Name,
+		Description:     params.Description,
+		Parameters:      jsonColumn(parameters, `{"type": "object"}`),
+		Url:             params.Url,
+		Method:          params.Method,
+		AuthHeader:      params.AuthHeader,
+		AuthValue:       params.AuthValue,
+		Timeout:         params.Timeout,
+		MaxResponseSize: params.MaxResponseSize,
+		IsActive:        isActive,
+	}
+}
@@ -0,0 +1,28 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package assistant
+
+import (
+	"flai/api/assistant/v1"
+	"flai/internal/logic/assistant"
+	"flai/internal/model/entity"
+)
+
+// assistantResponse decodes the current definition of an assistant.
+func assistantResponse(row *entity.Assistant) (*v1.AssistantResponse, error) {
+	definition, err := assistant.Definition(row)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.AssistantResponse{
+		Id:                  row.Id,
+		UserId:              row.UserId,
+		TeamId:              row.TeamId,
+		Version:             row.Version,
+		AssistantDefinition: *definition,
+		CreatedAt:           row.CreatedAt,
+		UpdatedAt:           row.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,15 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package assistant
+
+import (
+	"flai/api/assistant"
+)
+
+type ControllerV1 struct{}
+
+func NewV1() assistant.IAssistantV1 {
+	return &ControllerV1{}
+}
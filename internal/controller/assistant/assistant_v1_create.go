@@ -0,0 +1,42 @@
+package assistant
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic/assistant"
+	"flai/internal/middleware"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/assistant/v1"
+)
+
+func (c *ControllerV1) Create(ctx context.Context, req *v1.CreateReq) (res *v1.CreateRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+	if req.TeamId != "" {
+		role, err := dao.GetTeamRole(ctx, req.TeamId, user.Id)
+		if err != nil {
+			return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch team")
+		}
+		if role == "" {
+			return nil, gerror.NewCode(gcode.CodeNotFound, "Team not found")
+		}
+	}
+	if err = assistant.Check(ctx, user.Id, req.TeamId, &req.AssistantDefinition); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "Invalid assistant")
+	}
+
+	row, err := assistant.Create(ctx, user.Id, req.TeamId, &req.AssistantDefinition)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to create assistant")
+	}
+	response, err := assistantResponse(row)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to decode assistant")
+	}
+	return &v1.CreateRes{AssistantResponse: response}, nil
+}
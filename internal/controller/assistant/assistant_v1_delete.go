@@ -0,0 +1,35 @@
+package assistant
+
+import (
+	"context"
+	"flai/internal/logic/assistant"
+	"flai/internal/middleware"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/assistant/v1"
+)
+
+func (c *ControllerV1) Delete(ctx context.Context, req *v1.DeleteReq) (res *v1.DeleteRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	row, err := fetchAssistant(ctx, user.Id, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	canManage, err := assistant.CanManage(ctx, row, user.Id)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch team")
+	}
+	if !canManage {
+		return nil, gerror.NewCode(gcode.CodeNotAuthorized, "Only the owner can delete the assistant")
+	}
+	if err = assistant.Delete(ctx, row.Id); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to delete assistant")
+	}
+	return &v1.DeleteRes{}, nil
+}
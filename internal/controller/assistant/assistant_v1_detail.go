@@ -0,0 +1,51 @@
+package assistant
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic/assistant"
+	"flai/internal/middleware"
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/assistant/v1"
+)
+
+func (c *ControllerV1) Detail(ctx context.Context, req *v1.DetailReq) (res *v1.DetailRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	row, err := fetchAssistant(ctx, user.Id, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	response, err := assistantResponse(row)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to decode assistant")
+	}
+	if req.Version != 0 && req.Version != row.Version {
+		definition, err := assistant.FetchVersion(ctx, row.Id, req.Version)
+		if err != nil {
+			return nil, gerror.WrapCode(gcode.CodeNotFound, err, "Version not found")
+		}
+		response.Version = req.Version
+		response.AssistantDefinition = *definition
+	}
+	return &v1.DetailRes{AssistantResponse: response}, nil
+}
+
+// fetchAssistant returns an assistant the user can access.
+func fetchAssistant(ctx context.Context, userId string, id string) (*entity.Assistant, error) {
+	assistants, err := dao.FetchAccessibleAssistants(ctx, userId, id)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch assistant")
+	}
+	if len(assistants) == 0 {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Assistant not found")
+	}
+	return assistants[0], nil
+}
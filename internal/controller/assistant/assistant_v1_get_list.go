@@ -0,0 +1,33 @@
+package assistant
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/middleware"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/assistant/v1"
+)
+
+func (c *ControllerV1) GetList(ctx context.Context, req *v1.GetListReq) (res *v1.GetListRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	assistants, err := dao.FetchAccessibleAssistants(ctx, user.Id)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch assistants")
+	}
+	res = &v1.GetListRes{}
+	for _, row := range assistants {
+		response, err := assistantResponse(row)
+		if err != nil {
+			return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to decode assistant")
+		}
+		*res = append(*res, response)
+	}
+	return res, nil
+}
@@ -0,0 +1,29 @@
+package assistant
+
+import (
+	"context"
+	"flai/internal/logic/assistant"
+	"flai/internal/middleware"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/assistant/v1"
+)
+
+func (c *ControllerV1) StartConversation(ctx context.Context, req *v1.StartConversationReq) (res *v1.StartConversationRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	row, err := fetchAssistant(ctx, user.Id, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	conversation, err := assistant.StartConversation(ctx, user.Id, row)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to create conversation")
+	}
+	return &v1.StartConversationRes{Conversation: *conversation}, nil
+}
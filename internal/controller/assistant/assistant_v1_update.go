@@ -0,0 +1,44 @@
+package assistant
+
+import (
+	"context"
+	"flai/internal/logic/assistant"
+	"flai/internal/middleware"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/assistant/v1"
+)
+
+func (c *ControllerV1) Update(ctx context.Context, req *v1.UpdateReq) (res *v1.UpdateRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	row, err := fetchAssistant(ctx, user.Id, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	canManage, err := assistant.CanManage(ctx, row, user.Id)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch team")
+	}
+	if !canManage {
+		return nil, gerror.NewCode(gcode.CodeNotAuthorized, "Only the owner can update the assistant")
+	}
+	if err = assistant.Check(ctx, user.Id, row.TeamId, &req.AssistantDefinition); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "Invalid assistant")
+	}
+
+	row, err = assistant.Update(ctx, row.Id, &req.AssistantDefinition)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to update assistant")
+	}
+	response, err := assistantResponse(row)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to decode assistant")
+	}
+	return &v1.UpdateRes{AssistantResponse: response}, nil
+}
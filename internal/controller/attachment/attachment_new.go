@@ -0,0 +1,15 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package attachment
+
+import (
+	"flai/api/attachment"
+)
+
+type ControllerV1 struct{}
+
+func NewV1() attachment.IAttachmentV1 {
+	return &ControllerV1{}
+}
@@ -0,0 +1,44 @@
+package attachment
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic/attachment"
+	"flai/internal/middleware"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	"flai/api/attachment/v1"
+)
+
+func (c *ControllerV1) Download(ctx context.Context, req *v1.DownloadReq) (res *v1.DownloadRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	var file *entity.Attachment
+	err = dao.Attachment.Ctx(ctx).Where(do.Attachment{
+		Id:     req.Id,
+		UserId: user.Id,
+	}).Scan(&file)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch attachment")
+	}
+	if file == nil {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Attachment not found")
+	}
+	data, err := attachment.Read(ctx, file)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to read attachment")
+	}
+
+	response := g.RequestFromCtx(ctx).Response
+	attachment.SetHeaders(response, file)
+	response.Write(data)
+	return nil, nil
+}
@@ -0,0 +1,44 @@
+package attachment
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic/attachment"
+	"flai/internal/middleware"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"flai/api/attachment/v1"
+)
+
+func (c *ControllerV1) SignedUrl(ctx context.Context, req *v1.SignedUrlReq) (res *v1.SignedUrlRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	var file *entity.Attachment
+	err = dao.Attachment.Ctx(ctx).Where(do.Attachment{
+		Id:     req.Id,
+		UserId: user.Id,
+	}).Scan(&file)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch attachment")
+	}
+	if file == nil {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Attachment not found")
+	}
+
+	url, expiresAt, err := attachment.SignedURL(ctx, file)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to sign attachment url")
+	}
+	return &v1.SignedUrlRes{
+		Url:       url,
+		ExpiresAt: gtime.NewFromTime(expiresAt),
+	}, nil
+}
@@ -0,0 +1,36 @@
+package attachment
+
+import (
+	"context"
+	"flai/internal/logic/attachment"
+	"flai/internal/middleware"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/attachment/v1"
+)
+
+func (c *ControllerV1) Upload(ctx context.Context, req *v1.UploadReq) (res *v1.UploadRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "Failed to open uploaded file")
+	}
+	defer file.Close()
+
+	saved, err := attachment.Save(ctx, user.Id, req.File.Filename, file)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "Failed to save attachment")
+	}
+	return &v1.UploadRes{
+		Id:       saved.Id,
+		FileName: saved.FileName,
+		MimeType: saved.MimeType,
+		Size:     saved.Size,
+	}, nil
+}
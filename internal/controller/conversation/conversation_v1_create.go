@@ -3,6 +3,7 @@ package conversation
 import (
 	"context"
 	"flai/internal/dao"
+	"flai/internal/logic/llm"
 	"flai/internal/middleware"
 	"flai/internal/model/entity"
 
@@ -18,12 +19,16 @@ func (c *ControllerV1) Create(ctx context.Context, req *v1.CreateReq) (res *v1.C
 	if !ok {
 		return nil, gerror.New("User not found")
 	}
+	if err = llm.CheckSettings(req.Settings); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "Invalid settings")
+	}
 	conversation := entity.Conversation{
-		Id:     uuid.New().String(),
-		UserId: user.Id,
-		Title:  "Untitled",
+		Id:       uuid.New().String(),
+		UserId:   user.Id,
+		Title:    "Untitled",
+		Settings: req.Settings.String(),
 	}
-	_, err = dao.Conversation.Ctx(ctx).Data(conversation).Insert()
+	_, err = dao.Conversation.Ctx(ctx).Data(conversation).OmitEmptyData().Insert()
 	if err != nil {
 		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to create conversation")
 	}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"flai/api/conversation/v1"
 	"flai/internal/dao"
+	"flai/internal/logic/attachment"
 	"flai/internal/middleware"
 	"flai/internal/model/do"
 
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
 )
 
 func (c *ControllerV1) Delete(ctx context.Context, req *v1.DeleteReq) (res *v1.DeleteRes, err error) {
@@ -18,18 +20,36 @@ func (c *ControllerV1) Delete(ctx context.Context, req *v1.DeleteReq) (res *v1.D
 	}
 
 	conversationId := req.Id
-	_, err = dao.Conversation.Ctx(ctx).Delete(do.Conversation{
+	result, err := dao.Conversation.Ctx(ctx).Delete(do.Conversation{
 		Id:     conversationId,
 		UserId: user.Id,
 	})
 	if err != nil {
 		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to delete conversation")
 	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Conversation not found")
+	}
+	if err = attachment.PurgeByConversation(ctx, conversationId); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to delete attachments")
+	}
 	_, err = dao.Message.Ctx(ctx).Delete(do.Message{
 		ConversationId: conversationId,
 	})
 	if err != nil {
 		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to delete messages")
 	}
+	// Share links die with the conversation
+	_, err = dao.Share.Ctx(ctx).Data(do.Share{
+		RevokedAt: gtime.Now(),
+	}).Where(do.Share{
+		ConversationId: conversationId,
+		UserId:         user.Id,
+	}).
+		WhereNull("revoked_at").
+		Update()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to revoke share links")
+	}
 	return &v1.DeleteRes{}, nil
 }
@@ -68,6 +68,10 @@ func (c *ControllerV1) Detail(ctx context.Context, req *v1.DetailReq) (res *v1.D
 			return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to unmarshal message content")
 		}
 
+		for i := range contents {
+			// Signatures are only needed to continue the conversation
+			contents[i].Signature = ""
+		}
 		for _, content := range contents {
 			switch content.Type {
 			case consts.MessageType.Message:
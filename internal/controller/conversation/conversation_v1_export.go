@@ -0,0 +1,62 @@
+package conversation
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic/export"
+	"flai/internal/middleware"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+	"fmt"
+	"net/url"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	"flai/api/conversation/v1"
+)
+
+func (c *ControllerV1) Export(ctx context.Context, req *v1.ExportReq) (res *v1.ExportRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+	var conversation entity.Conversation
+	err = dao.Conversation.Ctx(ctx).Where(do.Conversation{
+		Id:     req.Id,
+		UserId: user.Id,
+	}).
+		WhereNull("deleted_at").
+		Scan(&conversation)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch conversation")
+	}
+	if conversation.Id == "" {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Conversation not found")
+	}
+
+	messages, err := dao.FetchConversationMessages(ctx, conversation.Id)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch messages")
+	}
+	conversationExport, err := export.Build(&conversation, messages)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to build export")
+	}
+	data, contentType, ext, err := export.Render(conversationExport, req.Format, req.MessageId)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "Failed to render export")
+	}
+
+	writeAttachment(ctx, contentType, export.FileName(conversationExport, ext), data)
+	return nil, nil
+}
+
+// writeAttachment writes data to the response as a file download.
+func writeAttachment(ctx context.Context, contentType string, fileName string, data []byte) {
+	response := g.RequestFromCtx(ctx).Response
+	response.Header().Set("Content-Type", contentType)
+	response.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename*=UTF-8''%s", url.PathEscape(fileName)))
+	response.Write(data)
+}
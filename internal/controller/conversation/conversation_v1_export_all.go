@@ -0,0 +1,67 @@
+package conversation
+
+import (
+	"bytes"
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic/export"
+	"flai/internal/middleware"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+	"fmt"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"flai/api/conversation/v1"
+)
+
+func (c *ControllerV1) ExportAll(ctx context.Context, req *v1.ExportAllReq) (res *v1.ExportAllRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	var conversations []*entity.Conversation
+	err = dao.Conversation.Ctx(ctx).Where(do.Conversation{
+		UserId: user.Id,
+	}).
+		WhereNull("deleted_at").
+		OrderAsc("created_at").
+		Scan(&conversations)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch conversations")
+	}
+
+	conversationIds := make([]string, 0, len(conversations))
+	for _, conversation := range conversations {
+		conversationIds = append(conversationIds, conversation.Id)
+	}
+	messages, err := dao.FetchConversationMessages(ctx, conversationIds...)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch messages")
+	}
+	messagesByConversation := make(map[string][]*entity.Message, len(conversations))
+	for _, msg := range messages {
+		messagesByConversation[msg.ConversationId] = append(messagesByConversation[msg.ConversationId], msg)
+	}
+
+	exports := make([]*export.ConversationExport, 0, len(conversations))
+	for _, conversation := range conversations {
+		conversationExport, err := export.Build(conversation, messagesByConversation[conversation.Id])
+		if err != nil {
+			return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to build export")
+		}
+		exports = append(exports, conversationExport)
+	}
+
+	var buf bytes.Buffer
+	if err = export.WriteArchive(&buf, exports, req.Format); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to write export archive")
+	}
+
+	fileName := fmt.Sprintf("flai-export-%s.zip", gtime.Now().Format("Ymd-His"))
+	writeAttachment(ctx, "application/zip", fileName, buf.Bytes())
+	return nil, nil
+}
@@ -0,0 +1,76 @@
+package conversation
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic/attachment"
+	"flai/internal/logic/branch"
+	"flai/internal/middleware"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/google/uuid"
+
+	"flai/api/conversation/v1"
+)
+
+func (c *ControllerV1) Fork(ctx context.Context, req *v1.ForkReq) (res *v1.ForkRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+	var source entity.Conversation
+	err = dao.Conversation.Ctx(ctx).Where(do.Conversation{
+		Id:     req.Id,
+		UserId: user.Id,
+	}).
+		WhereNull("deleted_at").
+		Scan(&source)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch conversation")
+	}
+	if source.Id == "" {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Conversation not found")
+	}
+
+	messages, err := dao.FetchConversationMessages(ctx, source.Id)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch messages")
+	}
+	chain, err := branch.AncestorChain(messages, req.MessageId)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeNotFound, err, "Message not found")
+	}
+
+	conversation := entity.Conversation{
+		Id:               uuid.New().String(),
+		UserId:           user.Id,
+		Title:            source.Title,
+		Icon:             source.Icon,
+		Settings:         source.Settings,
+		AssistantId:      source.AssistantId,
+		AssistantVersion: source.AssistantVersion,
+	}
+	forked := branch.Clone(chain, conversation.Id)
+	err = dao.Conversation.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		if _, err := dao.Conversation.Ctx(ctx).Data(conversation).OmitEmptyData().Insert(); err != nil {
+			return err
+		}
+		// The fork keeps its files when the source conversation is deleted
+		if err := attachment.CopyToClones(ctx, user.Id, chain, forked); err != nil {
+			return err
+		}
+		_, err := dao.Message.Ctx(ctx).Data(forked).Insert()
+		return err
+	})
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fork conversation")
+	}
+
+	return &v1.ForkRes{
+		Conversation: conversation,
+	}, nil
+}
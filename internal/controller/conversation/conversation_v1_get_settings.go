@@ -0,0 +1,43 @@
+package conversation
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/middleware"
+	"flai/internal/model"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/conversation/v1"
+)
+
+func (c *ControllerV1) GetSettings(ctx context.Context, req *v1.GetSettingsReq) (res *v1.GetSettingsRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+	var conversation entity.Conversation
+	err = dao.Conversation.Ctx(ctx).Where(do.Conversation{
+		Id:     req.Id,
+		UserId: user.Id,
+	}).
+		WhereNull("deleted_at").
+		Scan(&conversation)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch conversation")
+	}
+	if conversation.Id == "" {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Conversation not found")
+	}
+	settings, err := model.ParseChatSettings(conversation.Settings)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Invalid conversation settings")
+	}
+
+	return &v1.GetSettingsRes{
+		ChatSettings: *settings,
+	}, nil
+}
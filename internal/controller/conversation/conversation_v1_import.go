@@ -0,0 +1,65 @@
+package conversation
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic/importer"
+	"flai/internal/middleware"
+	"io"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/conversation/v1"
+)
+
+func (c *ControllerV1) Import(ctx context.Context, req *v1.ImportReq) (res *v1.ImportRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "Failed to open uploaded file")
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "Failed to read uploaded file")
+	}
+
+	result, err := importer.Parse(req.File.Filename, data, user.Id)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "Failed to parse import file")
+	}
+
+	res = &v1.ImportRes{
+		Conversations: make([]*v1.ImportedConversation, 0, len(result.Conversations)),
+		Skipped:       result.Skipped,
+	}
+	if res.Skipped == nil {
+		res.Skipped = make([]*importer.Skipped, 0)
+	}
+	err = dao.Conversation.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		for _, item := range result.Conversations {
+			if _, err := dao.Conversation.Ctx(ctx).Data(item.Conversation).OmitEmptyData().Insert(); err != nil {
+				return err
+			}
+			if _, err := dao.Message.Ctx(ctx).Data(item.Messages).Insert(); err != nil {
+				return err
+			}
+			res.Conversations = append(res.Conversations, &v1.ImportedConversation{
+				Id:           item.Conversation.Id,
+				Title:        item.Conversation.Title,
+				MessageCount: len(item.Messages),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to save imported conversations")
+	}
+	return res, nil
+}
@@ -0,0 +1,43 @@
+package conversation
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic/llm"
+	"flai/internal/middleware"
+	"flai/internal/model/do"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/conversation/v1"
+)
+
+func (c *ControllerV1) UpdateSettings(ctx context.Context, req *v1.UpdateSettingsReq) (res *v1.UpdateSettingsRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+	if err = llm.CheckSettings(&req.ChatSettings); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "Invalid settings")
+	}
+
+	result, err := dao.Conversation.Ctx(ctx).Data(do.Conversation{
+		Settings: req.ChatSettings.String(),
+	}).Where(do.Conversation{
+		Id:     req.Id,
+		UserId: user.Id,
+	}).
+		WhereNull("deleted_at").
+		Update()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to update settings")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Conversation not found")
+	}
+
+	return &v1.UpdateSettingsRes{
+		ChatSettings: req.ChatSettings,
+	}, nil
+}
@@ -0,0 +1,54 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package generation
+
+import (
+	"context"
+	"encoding/json"
+	"flai/api/generation/v1"
+	"flai/internal/dao"
+	"flai/internal/logic/generation"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// fetchGeneration returns a generation of the user.
+func fetchGeneration(ctx context.Context, userId string, id string) (*entity.Generation, error) {
+	var row entity.Generation
+	err := dao.Generation.Ctx(ctx).Where(do.Generation{
+		Id:     id,
+		UserId: userId,
+	}).Scan(&row)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch generation")
+	}
+	if row.Id == "" {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Generation not found")
+	}
+	return &row, nil
+}
+
+func generationResponse(row *entity.Generation) (*v1.GenerationResponse, error) {
+	messageIds := []string{}
+	if row.MessageIds != "" {
+		if err := json.Unmarshal([]byte(row.MessageIds), &messageIds); err != nil {
+			return nil, err
+		}
+	}
+	return &v1.GenerationResponse{
+		Id:              row.Id,
+		ConversationId:  row.ConversationId,
+		PromptMessageId: row.PromptMessageId,
+		MessageIds:      messageIds,
+		Status:          row.Status,
+		Error:           row.Error,
+		Attachable:      generation.Get(row.Id) != nil,
+		CreatedAt:       row.CreatedAt,
+		FinishedAt:      row.FinishedAt,
+	}, nil
+}
@@ -0,0 +1,15 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package generation
+
+import (
+	"flai/api/generation"
+)
+
+type ControllerV1 struct{}
+
+func NewV1() generation.IGenerationV1 {
+	return &ControllerV1{}
+}
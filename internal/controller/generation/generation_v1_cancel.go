@@ -0,0 +1,30 @@
+package generation
+
+import (
+	"context"
+	"flai/internal/consts"
+	"flai/internal/logic/generation"
+	"flai/internal/middleware"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/generation/v1"
+)
+
+func (c *ControllerV1) Cancel(ctx context.Context, req *v1.CancelReq) (res *v1.CancelRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	job := generation.Get(req.Id)
+	if job == nil || job.UserId != user.Id {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Generation not found")
+	}
+	if job.Status() != consts.GenerationStatus.Running {
+		return nil, gerror.NewCode(gcode.CodeInvalidOperation, "Generation has already ended")
+	}
+	job.Cancel()
+	return &v1.CancelRes{}, nil
+}
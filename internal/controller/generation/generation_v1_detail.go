@@ -0,0 +1,28 @@
+package generation
+
+import (
+	"context"
+	"flai/internal/middleware"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/generation/v1"
+)
+
+func (c *ControllerV1) Detail(ctx context.Context, req *v1.DetailReq) (res *v1.DetailRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	row, err := fetchGeneration(ctx, user.Id, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	response, err := generationResponse(row)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to decode generation")
+	}
+	return &v1.DetailRes{GenerationResponse: response}, nil
+}
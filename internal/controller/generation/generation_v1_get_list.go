@@ -0,0 +1,43 @@
+package generation
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/middleware"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/generation/v1"
+)
+
+func (c *ControllerV1) GetList(ctx context.Context, req *v1.GetListReq) (res *v1.GetListRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	where := do.Generation{
+		UserId:         user.Id,
+		ConversationId: req.ConversationId,
+	}
+	if req.Status != "" {
+		where.Status = req.Status
+	}
+	var rows []*entity.Generation
+	err = dao.Generation.Ctx(ctx).Where(where).OrderDesc("created_at").Scan(&rows)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch generations")
+	}
+	res = &v1.GetListRes{}
+	for _, row := range rows {
+		response, err := generationResponse(row)
+		if err != nil {
+			return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to decode generation")
+		}
+		*res = append(*res, response)
+	}
+	return res, nil
+}
@@ -0,0 +1,42 @@
+package generation
+
+import (
+	"context"
+	"flai/internal/logic/generation"
+	"flai/internal/middleware"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/util/gconv"
+
+	"flai/api/generation/v1"
+)
+
+func (c *ControllerV1) Stream(ctx context.Context, req *v1.StreamReq) (res *v1.StreamRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	// Events are only kept while the generation runs and for a while after,
+	// the answers of older ones are part of the conversation
+	job := generation.Get(req.Id)
+	if job == nil || job.UserId != user.Id {
+		if _, err = fetchGeneration(ctx, user.Id, req.Id); err != nil {
+			return nil, err
+		}
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Generation events are no longer available")
+	}
+
+	// Resume after the last event the client got
+	from := 0
+	if req.From != nil {
+		from = *req.From
+	} else if request := g.RequestFromCtx(ctx); request != nil {
+		if lastEventId := request.Header.Get("Last-Event-ID"); lastEventId != "" {
+			from = gconv.Int(lastEventId) + 1
+		}
+	}
+	return nil, generation.Attach(ctx, job, from)
+}
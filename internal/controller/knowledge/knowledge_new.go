@@ -0,0 +1,15 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package knowledge
+
+import (
+	"flai/api/knowledge"
+)
+
+type ControllerV1 struct{}
+
+func NewV1() knowledge.IKnowledgeV1 {
+	return &ControllerV1{}
+}
@@ -0,0 +1,57 @@
+package knowledge
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic"
+	"flai/internal/middleware"
+	"flai/internal/model/entity"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/google/uuid"
+
+	"flai/api/knowledge/v1"
+)
+
+func (c *ControllerV1) Create(ctx context.Context, req *v1.CreateReq) (res *v1.CreateRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "Name cannot be empty")
+	}
+	if logic.ProviderMap[req.ProviderId] == nil {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "Invalid provider ID")
+	}
+	if req.TeamId != "" {
+		role, err := dao.GetTeamRole(ctx, req.TeamId, user.Id)
+		if err != nil {
+			return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch team")
+		}
+		if role == "" {
+			return nil, gerror.NewCode(gcode.CodeNotFound, "Team not found")
+		}
+	}
+
+	knowledgeBase := entity.KnowledgeBase{
+		Id:             uuid.New().String(),
+		UserId:         user.Id,
+		TeamId:         req.TeamId,
+		Name:           name,
+		Description:    req.Description,
+		ProviderId:     req.ProviderId,
+		EmbeddingModel: req.EmbeddingModel,
+	}
+	_, err = dao.KnowledgeBase.Ctx(ctx).Data(knowledgeBase).OmitEmpty().Insert()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to create knowledge base")
+	}
+
+	return &v1.CreateRes{
+		Id: knowledgeBase.Id,
+	}, nil
+}
@@ -0,0 +1,35 @@
+package knowledge
+
+import (
+	"context"
+	"flai/internal/logic/knowledge"
+	"flai/internal/middleware"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/knowledge/v1"
+)
+
+func (c *ControllerV1) Delete(ctx context.Context, req *v1.DeleteReq) (res *v1.DeleteRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	knowledgeBase, err := fetchKnowledgeBase(ctx, user.Id, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	canManage, err := knowledge.CanManage(ctx, knowledgeBase, user.Id)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch team")
+	}
+	if !canManage {
+		return nil, gerror.NewCode(gcode.CodeNotAuthorized, "Only the owner can delete the knowledge base")
+	}
+	if err = knowledge.Delete(ctx, knowledgeBase); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to delete knowledge base")
+	}
+	return &v1.DeleteRes{}, nil
+}
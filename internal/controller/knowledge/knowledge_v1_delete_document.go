@@ -0,0 +1,53 @@
+package knowledge
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic/knowledge"
+	"flai/internal/middleware"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/knowledge/v1"
+)
+
+func (c *ControllerV1) DeleteDocument(ctx context.Context, req *v1.DeleteDocumentReq) (res *v1.DeleteDocumentRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	knowledgeBase, err := fetchKnowledgeBase(ctx, user.Id, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	var document *entity.KnowledgeDocument
+	err = dao.KnowledgeDocument.Ctx(ctx).Where(do.KnowledgeDocument{
+		Id:              req.DocumentId,
+		KnowledgeBaseId: knowledgeBase.Id,
+	}).Scan(&document)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch document")
+	}
+	if document == nil {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Document not found")
+	}
+
+	// Members may remove their own documents, managers any document
+	if document.UserId != user.Id {
+		canManage, err := knowledge.CanManage(ctx, knowledgeBase, user.Id)
+		if err != nil {
+			return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch team")
+		}
+		if !canManage {
+			return nil, gerror.NewCode(gcode.CodeNotAuthorized, "Only the uploader or the owner can delete the document")
+		}
+	}
+	if err = knowledge.DeleteDocuments(ctx, document.Id); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to delete document")
+	}
+	return &v1.DeleteDocumentRes{}, nil
+}
@@ -0,0 +1,52 @@
+package knowledge
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/middleware"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/knowledge/v1"
+)
+
+func (c *ControllerV1) Detail(ctx context.Context, req *v1.DetailReq) (res *v1.DetailRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	knowledgeBase, err := fetchKnowledgeBase(ctx, user.Id, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	var documents []*entity.KnowledgeDocument
+	err = dao.KnowledgeDocument.Ctx(ctx).Where(do.KnowledgeDocument{
+		KnowledgeBaseId: knowledgeBase.Id,
+	}).
+		OrderDesc("created_at").
+		Scan(&documents)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch documents")
+	}
+
+	return &v1.DetailRes{
+		KnowledgeBase: knowledgeBase,
+		Documents:     documents,
+	}, nil
+}
+
+// fetchKnowledgeBase returns a knowledge base the user can access.
+func fetchKnowledgeBase(ctx context.Context, userId string, id string) (*entity.KnowledgeBase, error) {
+	knowledgeBases, err := dao.FetchAccessibleKnowledgeBases(ctx, userId, id)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch knowledge base")
+	}
+	if len(knowledgeBases) == 0 {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Knowledge base not found")
+	}
+	return knowledgeBases[0], nil
+}
@@ -0,0 +1,27 @@
+package knowledge
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/middleware"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/knowledge/v1"
+)
+
+func (c *ControllerV1) GetList(ctx context.Context, req *v1.GetListReq) (res *v1.GetListRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	knowledgeBases, err := dao.FetchAccessibleKnowledgeBases(ctx, user.Id)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch knowledge bases")
+	}
+	res = &v1.GetListRes{}
+	*res = append(*res, knowledgeBases...)
+	return res, nil
+}
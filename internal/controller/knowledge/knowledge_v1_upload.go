@@ -0,0 +1,38 @@
+package knowledge
+
+import (
+	"context"
+	"flai/internal/logic/knowledge"
+	"flai/internal/middleware"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/knowledge/v1"
+)
+
+func (c *ControllerV1) Upload(ctx context.Context, req *v1.UploadReq) (res *v1.UploadRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	knowledgeBase, err := fetchKnowledgeBase(ctx, user.Id, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "Failed to open uploaded file")
+	}
+	defer file.Close()
+
+	document, err := knowledge.Ingest(ctx, knowledgeBase, user.Id, req.File.Filename, file)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "Failed to add document")
+	}
+	return &v1.UploadRes{
+		KnowledgeDocument: document,
+	}, nil
+}
@@ -3,3 +3,136 @@
 // =================================================================================
 
 package message
+
+import (
+	"context"
+	"encoding/json"
+	"flai/internal/consts"
+	"flai/internal/dao"
+	"flai/internal/logic"
+	"flai/internal/logic/attachment"
+	"flai/internal/logic/llm"
+	"flai/internal/model"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// fetchConversation returns the conversation if it belongs to the user.
+func fetchConversation(ctx context.Context, userId string, id string) (*entity.Conversation, error) {
+	var conversation entity.Conversation
+	err := dao.Conversation.Ctx(ctx).Where(do.Conversation{
+		Id:     id,
+		UserId: userId,
+	}).
+		WhereNull("deleted_at").
+		Scan(&conversation)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch conversation")
+	}
+	if conversation.Id == "" {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Conversation not found")
+	}
+	return &conversation, nil
+}
+
+// fetchHistory returns the messages of the path the prompt continues.
+func fetchHistory(ctx context.Context, messagePath []string) ([]*entity.Message, error) {
+	historyMessages, err := dao.FetchMessageHistory(ctx, messagePath)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch message history")
+	}
+	if len(historyMessages) != len(messagePath) {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "Invalid message path")
+	}
+	return historyMessages, nil
+}
+
+// resolveModel returns the provider and model config of the requested model,
+// aliases are routed to one of their models supporting the settings and
+// attachments.
+func resolveModel(role string, providerId string, modelName string, settings *model.ChatSettings, attachments []*entity.Attachment) (*llm.ChatModel, error) {
+	if providerId == consts.AliasProviderId {
+		modelAlias := logic.GetModelAlias(modelName)
+		if modelAlias == nil {
+			return nil, gerror.NewCode(gcode.CodeInvalidParameter, "Invalid model name")
+		}
+		providerInfo, modelConfig, err := modelAlias.Resolve(role, func(providerInfo *logic.SimpleProviderInfo, modelConfig *logic.ModelConfig) bool {
+			if llm.CheckModelSettings(providerInfo, modelConfig, settings) != nil {
+				return false
+			}
+			for _, file := range attachments {
+				if attachment.CheckModel(modelConfig, file) != nil {
+					return false
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "Model alias unavailable")
+		}
+		return &llm.ChatModel{ProviderInfo: providerInfo, ModelConfig: modelConfig, Alias: modelAlias.Name}, nil
+	}
+
+	providerInfo := logic.ProviderMap[providerId]
+	if providerInfo == nil {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "Invalid provider ID")
+	}
+	modelConfig := providerInfo.ModelIdMap[modelName]
+	if modelConfig == nil {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "Invalid model name")
+	}
+	return &llm.ChatModel{ProviderInfo: providerInfo, ModelConfig: modelConfig}, nil
+}
+
+// savePrompt stores the prompt as a message following the history and binds
+// the attachments to it.
+func savePrompt(ctx context.Context, id string, conversationId string, historyMessages []*entity.Message, attachments []*entity.Attachment, prompt string) (*entity.Message, error) {
+	var contents []llm.Content
+	for _, file := range attachments {
+		contents = append(contents, llm.Content{
+			Type: consts.MessageType.Attachment,
+			Data: llm.ContentAttachment{
+				Id:       file.Id,
+				FileName: file.FileName,
+				MimeType: file.MimeType,
+				Size:     file.Size,
+			},
+		})
+	}
+	if prompt != "" {
+		contents = append(contents, llm.Content{
+			Type: consts.MessageType.Message,
+			Data: llm.ContentMessage{Content: prompt},
+		})
+	}
+	contentByte, err := json.Marshal(contents)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to marshal message content")
+	}
+	var parentId string
+	if len(historyMessages) != 0 {
+		parentId = historyMessages[len(historyMessages)-1].Id
+	}
+	newMessage := &entity.Message{
+		Id:             id,
+		ConversationId: conversationId,
+		ParentId:       parentId,
+		Role:           consts.UserRole.User,
+		Content:        string(contentByte),
+		MetaInfo:       "{}",
+	}
+	_, err = dao.Message.Ctx(ctx).Insert(newMessage)
+	if err != nil {
+		if !strings.Contains(err.Error(), "duplicate key") {
+			return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to save message")
+		}
+	}
+	if err = attachment.Bind(ctx, attachments, newMessage.Id); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to save attachments")
+	}
+	return newMessage, nil
+}
@@ -0,0 +1,128 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"flai/api/message/v1"
+	"flai/internal/logic/assistant"
+	"flai/internal/logic/attachment"
+	"flai/internal/logic/generation"
+	"flai/internal/logic/knowledge"
+	"flai/internal/logic/llm"
+	"flai/internal/middleware"
+	"flai/internal/model"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	minCompareModels = 2
+	maxCompareModels = 4
+)
+
+func (c *ControllerV1) Compare(ctx context.Context, req *v1.CompareReq) (res *v1.CompareRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+	if len(req.Models) < minCompareModels || len(req.Models) > maxCompareModels {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, "Between %d and %d models can be compared", minCompareModels, maxCompareModels)
+	}
+
+	conversation, err := fetchConversation(ctx, user.Id, req.ConversationId)
+	if err != nil {
+		return nil, err
+	}
+	historyMessages, err := fetchHistory(ctx, req.MessagePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// The models are always picked explicitly, only tools and knowledge fall
+	// back to the assistant
+	if conversation.AssistantId != "" {
+		definition, err := assistant.FetchVersion(ctx, conversation.AssistantId, conversation.AssistantVersion)
+		if err != nil {
+			return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch assistant")
+		}
+		if err = assistant.DropUnavailable(ctx, user.Id, definition); err != nil {
+			return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to check assistant")
+		}
+		if req.Tools == nil {
+			req.Tools = definition.Tools
+		}
+		if req.KnowledgeBases == nil {
+			req.KnowledgeBases = definition.KnowledgeBases
+		}
+	}
+
+	if err = llm.CheckTools(req.Tools); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "Invalid tools")
+	}
+
+	settings, err := model.ParseChatSettings(conversation.Settings)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Invalid conversation settings")
+	}
+	settings = settings.Merge(req.Settings)
+
+	attachments, err := attachment.FetchUnbound(ctx, user.Id, req.Attachments, req.Id)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "Invalid attachments")
+	}
+
+	// Every model has to support the settings and attachments
+	chatModels := make([]*llm.ChatModel, 0, len(req.Models))
+	for _, requested := range req.Models {
+		chatModel, err := resolveModel(user.Role, requested.ProviderId, requested.ModelName, settings, attachments)
+		if err != nil {
+			return nil, err
+		}
+		if err = llm.CheckModelSettings(chatModel.ProviderInfo, chatModel.ModelConfig, settings); err != nil {
+			return nil, gerror.WrapCodef(gcode.CodeInvalidParameter, err, "Invalid settings for model %s", chatModel.ModelConfig.Name)
+		}
+		for _, file := range attachments {
+			if err = attachment.CheckModel(chatModel.ModelConfig, file); err != nil {
+				return nil, gerror.WrapCodef(gcode.CodeInvalidParameter, err, "Unsupported attachment for model %s", chatModel.ModelConfig.Name)
+			}
+		}
+		chatModels = append(chatModels, chatModel)
+	}
+
+	prompt := strings.TrimSpace(req.Prompt)
+	if prompt == "" && len(attachments) == 0 {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "Prompt cannot be empty")
+	}
+
+	knowledgeHits, err := knowledge.Retrieve(ctx, user.Id, req.KnowledgeBases, prompt)
+	if errors.Is(err, knowledge.ErrNotFound) {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "Invalid knowledge bases")
+	}
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to retrieve knowledge")
+	}
+
+	newMessage, err := savePrompt(ctx, req.Id, req.ConversationId, historyMessages, attachments, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Failures of single models are streamed as error events
+	options := &llm.ChatOptions{
+		UserId:    user.Id,
+		Tools:     req.Tools,
+		Knowledge: knowledgeHits,
+		Settings:  settings,
+	}
+	job, err := generation.Start(ctx, user.Id, req.ConversationId, newMessage.Id, func(ctx context.Context, sink llm.Sink) error {
+		llm.CompareChat(ctx, sink, chatModels, historyMessages, newMessage, options)
+		return nil
+	})
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to start generation")
+	}
+
+	return nil, generation.Attach(ctx, job, 0)
+}
@@ -2,20 +2,19 @@ package message
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"flai/api/message/v1"
-	"flai/internal/consts"
-	"flai/internal/dao"
-	"flai/internal/logic"
+	"flai/internal/logic/assistant"
+	"flai/internal/logic/attachment"
+	"flai/internal/logic/generation"
+	"flai/internal/logic/knowledge"
 	"flai/internal/logic/llm"
 	"flai/internal/middleware"
-	"flai/internal/model/do"
-	"flai/internal/model/entity"
+	"flai/internal/model"
 	"strings"
 
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
-	"github.com/gogf/gf/v2/frame/g"
 )
 
 func (c *ControllerV1) Create(ctx context.Context, req *v1.CreateReq) (res *v1.CreateRes, err error) {
@@ -25,88 +24,105 @@ func (c *ControllerV1) Create(ctx context.Context, req *v1.CreateReq) (res *v1.C
 	}
 
 	// Make sure user has access to the conversation
-	var conversation entity.Conversation
-	err = dao.Conversation.Ctx(ctx).Where(do.Conversation{
-		Id:     req.ConversationId,
-		UserId: user.Id,
-	}).
-		WhereNull("deleted_at").
-		Scan(&conversation)
+	conversation, err := fetchConversation(ctx, user.Id, req.ConversationId)
 	if err != nil {
-		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch conversation")
-	}
-	if conversation.Id == "" {
-		return nil, gerror.NewCode(gcode.CodeNotFound, "Conversation not found")
+		return nil, err
 	}
 
 	// Fetch message history based on MessagePath
-	historyMessages, err := dao.FetchMessageHistory(ctx, req.MessagePath)
+	historyMessages, err := fetchHistory(ctx, req.MessagePath)
 	if err != nil {
-		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch message history")
-	}
-	if len(historyMessages) != len(req.MessagePath) {
-		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "Invalid message path")
+		return nil, err
 	}
 
-	// Save prompt to new message
-	prompt := strings.TrimSpace(req.Prompt)
-	if prompt == "" {
-		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "Prompt cannot be empty")
+	// Conversations started from an assistant fall back to the version it had
+	// at that time
+	if conversation.AssistantId != "" {
+		definition, err := assistant.FetchVersion(ctx, conversation.AssistantId, conversation.AssistantVersion)
+		if err != nil {
+			return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch assistant")
+		}
+		if err = assistant.DropUnavailable(ctx, user.Id, definition); err != nil {
+			return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to check assistant")
+		}
+		if req.ProviderId == "" && req.ModelName == "" {
+			req.ProviderId = definition.ProviderId
+			req.ModelName = definition.ModelName
+		}
+		if req.Tools == nil {
+			req.Tools = definition.Tools
+		}
+		if req.KnowledgeBases == nil {
+			req.KnowledgeBases = definition.KnowledgeBases
+		}
 	}
-	data := llm.ContentMessage{
-		Content: prompt,
+
+	if err = llm.CheckTools(req.Tools); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "Invalid tools")
 	}
-	content := llm.Content{
-		Type: consts.MessageType.Message,
-		Data: data,
+
+	// Request settings override the ones saved on the conversation
+	settings, err := model.ParseChatSettings(conversation.Settings)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Invalid conversation settings")
 	}
-	contentByte, err := json.Marshal([]llm.Content{content})
+	settings = settings.Merge(req.Settings)
+
+	// Make sure the attachments belong to the user
+	attachments, err := attachment.FetchUnbound(ctx, user.Id, req.Attachments, req.Id)
 	if err != nil {
-		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to marshal message content")
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "Invalid attachments")
 	}
-	var parentId string
-	if len(historyMessages) != 0 {
-		parentId = historyMessages[len(historyMessages)-1].Id
+
+	// Get provider and model config, the model has to accept the settings and
+	// attachments
+	chatModel, err := resolveModel(user.Role, req.ProviderId, req.ModelName, settings, attachments)
+	if err != nil {
+		return nil, err
 	}
-	newMessage := &entity.Message{
-		Id:             req.Id,
-		ConversationId: req.ConversationId,
-		ParentId:       parentId,
-		Role:           consts.UserRole.User,
-		Content:        string(contentByte),
-		MetaInfo:       "{}",
+	if err = llm.CheckModelSettings(chatModel.ProviderInfo, chatModel.ModelConfig, settings); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "Invalid settings")
 	}
-	_, err = dao.Message.Ctx(ctx).Insert(newMessage)
-	if err != nil {
-		if !strings.Contains(err.Error(), "duplicate key") {
-			return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to save message")
+	for _, file := range attachments {
+		if err = attachment.CheckModel(chatModel.ModelConfig, file); err != nil {
+			return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "Unsupported attachment")
 		}
 	}
 
-	// Get provider and model config
-	providerInfo := logic.ProviderMap[req.ProviderId]
-	if providerInfo == nil {
-		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "Invalid provider ID")
+	prompt := strings.TrimSpace(req.Prompt)
+	if prompt == "" && len(attachments) == 0 {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "Prompt cannot be empty")
+	}
+
+	// Retrieve knowledge base context for the prompt
+	knowledgeHits, err := knowledge.Retrieve(ctx, user.Id, req.KnowledgeBases, prompt)
+	if errors.Is(err, knowledge.ErrNotFound) {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, "Invalid knowledge bases")
 	}
-	modelConfig := providerInfo.ModelIdMap[req.ModelName]
-	if modelConfig == nil {
-		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "Invalid model name")
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to retrieve knowledge")
 	}
 
-	request := g.RequestFromCtx(ctx)
-	if request == nil {
-		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "Invalid request")
-	}
-	response := request.Response
-	response.Header().Set("Content-Type", "text/event-stream")
-	response.Header().Set("Cache-Control", "no-cache")
-	response.Header().Set("Connection", "keep-alive")
-	response.Header().Set("Access-Control-Allow-Origin", "*")
+	// Save prompt to new message
+	newMessage, err := savePrompt(ctx, req.Id, req.ConversationId, historyMessages, attachments, prompt)
+	if err != nil {
+		return nil, err
+	}
 
-	err = llm.StreamChat(ctx, response, providerInfo, modelConfig, historyMessages, newMessage, req.Tools)
+	// The answer is generated in the background, the client only follows it
+	options := &llm.ChatOptions{
+		UserId:    user.Id,
+		Tools:     req.Tools,
+		Knowledge: knowledgeHits,
+		Settings:  settings,
+		Alias:     chatModel.Alias,
+	}
+	job, err := generation.Start(ctx, user.Id, req.ConversationId, newMessage.Id, func(ctx context.Context, sink llm.Sink) error {
+		return llm.StreamChat(ctx, sink, chatModel.ProviderInfo, chatModel.ModelConfig, historyMessages, newMessage, options)
+	})
 	if err != nil {
-		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to stream message")
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to start generation")
 	}
 
-	return nil, nil
+	return nil, generation.Attach(ctx, job, 0)
 }
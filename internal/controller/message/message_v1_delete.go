@@ -3,6 +3,7 @@ package message
 import (
 	"context"
 	"flai/internal/dao"
+	"flai/internal/logic/attachment"
 	"flai/internal/middleware"
 	"flai/internal/model/do"
 	"flai/internal/model/entity"
@@ -36,12 +37,15 @@ func (c *ControllerV1) Delete(ctx context.Context, req *v1.DeleteReq) (res *v1.D
 
 	// Delete message
 	if req.Id != "" {
-		_, err = dao.Message.Ctx(ctx).Delete(do.Message{
-			Id:             req.Id,
-			ConversationId: req.ConversationId,
-		})
+		messageIds, err := deleteMessages(ctx, req.ConversationId, req.Id)
 		if err != nil {
-			return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to delete message")
+			return nil, err
+		}
+		if len(messageIds) == 0 {
+			return nil, gerror.NewCode(gcode.CodeNotFound, "Message not found")
+		}
+		if err = attachment.PurgeByMessages(ctx, messageIds...); err != nil {
+			return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to delete attachments")
 		}
 		// Change children's parent
 		if len(req.Children) > 0 {
@@ -57,15 +61,43 @@ func (c *ControllerV1) Delete(ctx context.Context, req *v1.DeleteReq) (res *v1.D
 		}
 		return nil, nil
 	} else if len(req.Ids) > 0 {
-		_, err = dao.Message.Ctx(ctx).Delete(do.Message{
-			Id:             req.Ids,
-			ConversationId: req.ConversationId,
-		})
+		messageIds, err := deleteMessages(ctx, req.ConversationId, req.Ids)
 		if err != nil {
-			return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to delete message")
+			return nil, err
+		}
+		if err = attachment.PurgeByMessages(ctx, messageIds...); err != nil {
+			return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to delete attachments")
 		}
 		return nil, nil
 	}
 
 	return nil, gerror.NewCode(gcode.CodeInternalError, "Ids and id cannot be both empty")
 }
+
+// deleteMessages deletes the messages of the conversation among ids and
+// returns the ids that were deleted. Ids of other conversations are ignored.
+func deleteMessages(ctx context.Context, conversationId string, ids any) ([]string, error) {
+	where := do.Message{
+		Id:             ids,
+		ConversationId: conversationId,
+	}
+	values, err := dao.Message.Ctx(ctx).Where(where).Array("id")
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch messages")
+	}
+	messageIds := make([]string, 0, len(values))
+	for _, value := range values {
+		messageIds = append(messageIds, value.String())
+	}
+	if len(messageIds) == 0 {
+		return messageIds, nil
+	}
+	_, err = dao.Message.Ctx(ctx).Delete(do.Message{
+		Id:             messageIds,
+		ConversationId: conversationId,
+	})
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to delete message")
+	}
+	return messageIds, nil
+}
@@ -3,10 +3,14 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"flai/internal/consts"
 	"flai/internal/dao"
 	"flai/internal/logic"
+	"flai/internal/middleware"
 	"flai/internal/model/do"
 	"flai/internal/model/entity"
+	"slices"
+	"strings"
 
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
@@ -41,5 +45,28 @@ func (c *ControllerV1) List(ctx context.Context, req *v1.ListReq) (res *v1.ListR
 			Logo:         provider.Logo,
 		})
 	}
+
+	// Aliases are listed as the models of one virtual provider
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return res, nil
+	}
+	var aliases []logic.ModelConfig
+	for _, alias := range logic.ModelAliases() {
+		if alias.Available(user.Role) {
+			aliases = append(aliases, *alias.Config())
+		}
+	}
+	if len(aliases) > 0 {
+		slices.SortFunc(aliases, func(a, b logic.ModelConfig) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		*res = append(*res, v1.SimpleProvider{
+			Id:           consts.AliasProviderId,
+			Name:         "Aliases",
+			ProviderType: consts.AliasProviderId,
+			Model:        aliases,
+		})
+	}
 	return res, nil
 }
@@ -0,0 +1,42 @@
+package public
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic/attachment"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	"flai/api/public/v1"
+)
+
+func (c *ControllerV1) Attachment(ctx context.Context, req *v1.AttachmentReq) (res *v1.AttachmentRes, err error) {
+	if !attachment.VerifySignature(ctx, req.Id, req.Expires, req.Signature) {
+		return nil, gerror.NewCode(gcode.CodeNotAuthorized, "Invalid or expired signature")
+	}
+
+	var file *entity.Attachment
+	err = dao.Attachment.Ctx(ctx).Where(do.Attachment{
+		Id: req.Id,
+	}).Scan(&file)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch attachment")
+	}
+	if file == nil {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Attachment not found")
+	}
+	data, err := attachment.Read(ctx, file)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to read attachment")
+	}
+
+	response := g.RequestFromCtx(ctx).Response
+	attachment.SetHeaders(response, file)
+	response.Header().Set("Cache-Control", "private, max-age=300")
+	response.Write(data)
+	return nil, nil
+}
@@ -0,0 +1,42 @@
+package public
+
+import (
+	"context"
+	"encoding/json"
+	"flai/internal/dao"
+	"flai/internal/logic/export"
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/public/v1"
+)
+
+func (c *ControllerV1) ShareView(ctx context.Context, req *v1.ShareViewReq) (res *v1.ShareViewRes, err error) {
+	share, err := dao.GetActiveShare(ctx, req.Id)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch share link")
+	}
+	if share == nil {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Share link not found")
+	}
+
+	var messages []*entity.Message
+	if err = json.Unmarshal([]byte(share.Snapshot), &messages); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to unmarshal snapshot")
+	}
+	shared, err := export.Build(&entity.Conversation{Title: share.Title, Icon: share.Icon}, messages)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to build shared thread")
+	}
+
+	return &v1.ShareViewRes{
+		Id:        share.Id,
+		Title:     share.Title,
+		Icon:      share.Icon,
+		Messages:  shared.Messages,
+		CreatedAt: share.CreatedAt,
+		ExpiresAt: share.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,15 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package search
+
+import (
+	"flai/api/search"
+)
+
+type ControllerV1 struct{}
+
+func NewV1() search.ISearchV1 {
+	return &ControllerV1{}
+}
@@ -0,0 +1,43 @@
+package search
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/middleware"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/search/v1"
+)
+
+func (c *ControllerV1) Search(ctx context.Context, req *v1.SearchReq) (res *v1.SearchRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	keyword := strings.TrimSpace(req.Q)
+	if keyword == "" {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "Search query cannot be empty")
+	}
+	if req.Current <= 0 {
+		req.Current = 1
+	}
+	if req.Size <= 0 || req.Size > 100 {
+		req.Size = 20
+	}
+
+	hits, total, err := dao.SearchMessages(ctx, user.Id, keyword, req.Current, req.Size)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to search messages")
+	}
+
+	return &v1.SearchRes{
+		Size:    req.Size,
+		Current: req.Current,
+		Total:   total,
+		Records: hits,
+	}, nil
+}
@@ -0,0 +1,75 @@
+package share
+
+import (
+	"context"
+	"encoding/json"
+	"flai/internal/dao"
+	"flai/internal/logic/branch"
+	"flai/internal/middleware"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/google/uuid"
+
+	"flai/api/share/v1"
+)
+
+func (c *ControllerV1) Create(ctx context.Context, req *v1.CreateReq) (res *v1.CreateRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(gtime.Now()) {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "Expiry must be in the future")
+	}
+
+	var conversation entity.Conversation
+	err = dao.Conversation.Ctx(ctx).Where(do.Conversation{
+		Id:     req.ConversationId,
+		UserId: user.Id,
+	}).
+		WhereNull("deleted_at").
+		Scan(&conversation)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch conversation")
+	}
+	if conversation.Id == "" {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Conversation not found")
+	}
+
+	// Snapshot the branch so later changes to the conversation stay private
+	messages, err := dao.FetchConversationMessages(ctx, conversation.Id)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch messages")
+	}
+	chain, err := branch.AncestorChain(messages, req.MessageId)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeNotFound, err, "Message not found")
+	}
+	snapshot, err := json.Marshal(chain)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to marshal snapshot")
+	}
+
+	share := entity.Share{
+		Id:             uuid.New().String(),
+		UserId:         user.Id,
+		ConversationId: conversation.Id,
+		MessageId:      req.MessageId,
+		Title:          conversation.Title,
+		Icon:           conversation.Icon,
+		Snapshot:       string(snapshot),
+		ExpiresAt:      req.ExpiresAt,
+	}
+	_, err = dao.Share.Ctx(ctx).Data(share).Insert()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to create share link")
+	}
+
+	return &v1.CreateRes{
+		Id: share.Id,
+	}, nil
+}
@@ -0,0 +1,67 @@
+package share
+
+import (
+	"context"
+	"encoding/json"
+	"flai/internal/dao"
+	"flai/internal/logic/attachment"
+	"flai/internal/logic/branch"
+	"flai/internal/middleware"
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/google/uuid"
+
+	"flai/api/share/v1"
+)
+
+func (c *ControllerV1) Fork(ctx context.Context, req *v1.ForkReq) (res *v1.ForkRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	share, err := dao.GetActiveShare(ctx, req.Id)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch share link")
+	}
+	if share == nil {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Share link not found")
+	}
+	var messages []*entity.Message
+	if err = json.Unmarshal([]byte(share.Snapshot), &messages); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to unmarshal snapshot")
+	}
+
+	conversation := entity.Conversation{
+		Id:       uuid.New().String(),
+		UserId:   user.Id,
+		Title:    share.Title,
+		Icon:     share.Icon,
+		Settings: "{}",
+	}
+	forked := branch.Clone(messages, conversation.Id)
+	err = dao.Conversation.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		if _, err := dao.Conversation.Ctx(ctx).Data(conversation).OmitEmptyData().Insert(); err != nil {
+			return err
+		}
+		if len(forked) == 0 {
+			return nil
+		}
+		// The fork keeps its files when the shared conversation is deleted
+		if err := attachment.CopyToClones(ctx, user.Id, messages, forked); err != nil {
+			return err
+		}
+		_, err := dao.Message.Ctx(ctx).Data(forked).Insert()
+		return err
+	})
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fork conversation")
+	}
+
+	return &v1.ForkRes{
+		Conversation: conversation,
+	}, nil
+}
@@ -0,0 +1,46 @@
+package share
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/middleware"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/share/v1"
+)
+
+func (c *ControllerV1) GetList(ctx context.Context, req *v1.GetListReq) (res *v1.GetListRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	var shares []*entity.Share
+	err = dao.Share.Ctx(ctx).Where(do.Share{
+		UserId: user.Id,
+	}).
+		OrderDesc("created_at").
+		Scan(&shares)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch share links")
+	}
+
+	res = &v1.GetListRes{}
+	for _, share := range shares {
+		*res = append(*res, &v1.ShareResponse{
+			Id:             share.Id,
+			ConversationId: share.ConversationId,
+			MessageId:      share.MessageId,
+			Title:          share.Title,
+			Icon:           share.Icon,
+			ExpiresAt:      share.ExpiresAt,
+			RevokedAt:      share.RevokedAt,
+			CreatedAt:      share.CreatedAt,
+		})
+	}
+	return res, nil
+}
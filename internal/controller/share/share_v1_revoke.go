@@ -0,0 +1,37 @@
+package share
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/middleware"
+	"flai/internal/model/do"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+
+	"flai/api/share/v1"
+)
+
+func (c *ControllerV1) Revoke(ctx context.Context, req *v1.RevokeReq) (res *v1.RevokeRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	result, err := dao.Share.Ctx(ctx).Data(do.Share{
+		RevokedAt: gtime.Now(),
+	}).Where(do.Share{
+		Id:     req.Id,
+		UserId: user.Id,
+	}).
+		WhereNull("revoked_at").
+		Update()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to revoke share link")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Share link not found")
+	}
+	return &v1.RevokeRes{}, nil
+}
@@ -0,0 +1,15 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package team
+
+import (
+	"flai/api/team"
+)
+
+type ControllerV1 struct{}
+
+func NewV1() team.ITeamV1 {
+	return &ControllerV1{}
+}
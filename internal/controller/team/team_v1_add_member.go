@@ -0,0 +1,52 @@
+package team
+
+import (
+	"context"
+	"flai/internal/consts"
+	"flai/internal/dao"
+	"flai/internal/middleware"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/team/v1"
+)
+
+func (c *ControllerV1) AddMember(ctx context.Context, req *v1.AddMemberReq) (res *v1.AddMemberRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+	role, err := dao.GetTeamRole(ctx, req.Id, user.Id)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch team")
+	}
+	if role != consts.TeamRole.Owner {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Team not found")
+	}
+
+	var member *entity.User
+	err = dao.User.Ctx(ctx).Where(do.User{
+		Email: req.Email,
+	}).Scan(&member)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch user")
+	}
+	if member == nil {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "User not found")
+	}
+
+	_, err = dao.TeamMember.Ctx(ctx).Data(entity.TeamMember{
+		TeamId: req.Id,
+		UserId: member.Id,
+		Role:   consts.TeamRole.Member,
+	}).
+		OmitEmpty().
+		InsertIgnore()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to add team member")
+	}
+	return &v1.AddMemberRes{}, nil
+}
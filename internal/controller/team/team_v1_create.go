@@ -0,0 +1,52 @@
+package team
+
+import (
+	"context"
+	"flai/internal/consts"
+	"flai/internal/dao"
+	"flai/internal/middleware"
+	"flai/internal/model/entity"
+	"strings"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/google/uuid"
+
+	"flai/api/team/v1"
+)
+
+func (c *ControllerV1) Create(ctx context.Context, req *v1.CreateReq) (res *v1.CreateRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "Name cannot be empty")
+	}
+
+	team := entity.Team{
+		Id:      uuid.New().String(),
+		Name:    name,
+		OwnerId: user.Id,
+	}
+	err = dao.Team.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		if _, err := dao.Team.Ctx(ctx).Data(team).OmitEmpty().Insert(); err != nil {
+			return err
+		}
+		_, err := dao.TeamMember.Ctx(ctx).Data(entity.TeamMember{
+			TeamId: team.Id,
+			UserId: user.Id,
+			Role:   consts.TeamRole.Owner,
+		}).OmitEmpty().Insert()
+		return err
+	})
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to create team")
+	}
+
+	return &v1.CreateRes{
+		Id: team.Id,
+	}, nil
+}
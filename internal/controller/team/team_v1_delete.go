@@ -0,0 +1,64 @@
+package team
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic/knowledge"
+	"flai/internal/middleware"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/team/v1"
+)
+
+func (c *ControllerV1) Delete(ctx context.Context, req *v1.DeleteReq) (res *v1.DeleteRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	err = dao.Team.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		result, err := dao.Team.Ctx(ctx).Where(do.Team{
+			Id:      req.Id,
+			OwnerId: user.Id,
+		}).Delete()
+		if err != nil {
+			return err
+		}
+		if affected, _ := result.RowsAffected(); affected == 0 {
+			return gerror.NewCode(gcode.CodeNotFound, "Team not found")
+		}
+
+		_, err = dao.TeamMember.Ctx(ctx).Where(do.TeamMember{
+			TeamId: req.Id,
+		}).Delete()
+		if err != nil {
+			return err
+		}
+
+		var knowledgeBases []*entity.KnowledgeBase
+		err = dao.KnowledgeBase.Ctx(ctx).Where(do.KnowledgeBase{
+			TeamId: req.Id,
+		}).Scan(&knowledgeBases)
+		if err != nil {
+			return err
+		}
+		for _, knowledgeBase := range knowledgeBases {
+			if err = knowledge.Delete(ctx, knowledgeBase); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if gerror.Code(err) == gcode.CodeNotFound {
+		return nil, err
+	}
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to delete team")
+	}
+	return &v1.DeleteRes{}, nil
+}
@@ -0,0 +1,59 @@
+package team
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/middleware"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/team/v1"
+)
+
+func (c *ControllerV1) GetList(ctx context.Context, req *v1.GetListReq) (res *v1.GetListRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+
+	var members []*entity.TeamMember
+	err = dao.TeamMember.Ctx(ctx).Where(do.TeamMember{
+		UserId: user.Id,
+	}).Scan(&members)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch teams")
+	}
+	res = &v1.GetListRes{}
+	if len(members) == 0 {
+		return res, nil
+	}
+	roles := make(map[string]string, len(members))
+	teamIds := make([]string, 0, len(members))
+	for _, member := range members {
+		roles[member.TeamId] = member.Role
+		teamIds = append(teamIds, member.TeamId)
+	}
+
+	var teams []*entity.Team
+	err = dao.Team.Ctx(ctx).Where(do.Team{
+		Id: teamIds,
+	}).
+		OrderAsc("created_at").
+		Scan(&teams)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch teams")
+	}
+	for _, team := range teams {
+		*res = append(*res, &v1.TeamResponse{
+			Id:        team.Id,
+			Name:      team.Name,
+			OwnerId:   team.OwnerId,
+			Role:      roles[team.Id],
+			CreatedAt: team.CreatedAt,
+		})
+	}
+	return res, nil
+}
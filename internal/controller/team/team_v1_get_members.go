@@ -0,0 +1,72 @@
+package team
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/middleware"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/team/v1"
+)
+
+func (c *ControllerV1) GetMembers(ctx context.Context, req *v1.GetMembersReq) (res *v1.GetMembersRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+	role, err := dao.GetTeamRole(ctx, req.Id, user.Id)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch team")
+	}
+	if role == "" {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Team not found")
+	}
+
+	var members []*entity.TeamMember
+	err = dao.TeamMember.Ctx(ctx).Where(do.TeamMember{
+		TeamId: req.Id,
+	}).
+		OrderAsc("created_at").
+		Scan(&members)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch team members")
+	}
+	userIds := make([]string, 0, len(members))
+	for _, member := range members {
+		userIds = append(userIds, member.UserId)
+	}
+	var users []*entity.User
+	if len(userIds) > 0 {
+		err = dao.User.Ctx(ctx).Where(do.User{
+			Id: userIds,
+		}).Scan(&users)
+		if err != nil {
+			return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch users")
+		}
+	}
+	userMap := make(map[string]*entity.User, len(users))
+	for _, u := range users {
+		userMap[u.Id] = u
+	}
+
+	res = &v1.GetMembersRes{}
+	for _, member := range members {
+		u, ok := userMap[member.UserId]
+		if !ok {
+			continue
+		}
+		*res = append(*res, &v1.MemberResponse{
+			UserId:    u.Id,
+			Username:  u.Username,
+			Email:     u.Email,
+			Avatar:    u.Avatar,
+			Role:      member.Role,
+			CreatedAt: member.CreatedAt,
+		})
+	}
+	return res, nil
+}
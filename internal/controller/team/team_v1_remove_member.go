@@ -0,0 +1,43 @@
+package team
+
+import (
+	"context"
+	"flai/internal/consts"
+	"flai/internal/dao"
+	"flai/internal/middleware"
+	"flai/internal/model/do"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"flai/api/team/v1"
+)
+
+func (c *ControllerV1) RemoveMember(ctx context.Context, req *v1.RemoveMemberReq) (res *v1.RemoveMemberRes, err error) {
+	user, ok := middleware.GetUserFromContext(ctx)
+	if !ok {
+		return nil, gerror.New("User not found")
+	}
+	role, err := dao.GetTeamRole(ctx, req.Id, user.Id)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to fetch team")
+	}
+	if role == "" || (role != consts.TeamRole.Owner && req.UserId != user.Id) {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Team not found")
+	}
+	if role == consts.TeamRole.Owner && req.UserId == user.Id {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "The team owner cannot leave the team")
+	}
+
+	result, err := dao.TeamMember.Ctx(ctx).Where(do.TeamMember{
+		TeamId: req.Id,
+		UserId: req.UserId,
+	}).Delete()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, "Failed to remove team member")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, gerror.NewCode(gcode.CodeNotFound, "Team member not found")
+	}
+	return &v1.RemoveMemberRes{}, nil
+}
@@ -0,0 +1,15 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package tool
+
+import (
+	"flai/api/tool"
+)
+
+type ControllerV1 struct{}
+
+func NewV1() tool.IToolV1 {
+	return &ControllerV1{}
+}
@@ -0,0 +1,30 @@
+package tool
+
+import (
+	"context"
+	"flai/internal/consts"
+	"flai/internal/logic/llm"
+
+	"flai/api/tool/v1"
+)
+
+func (c *ControllerV1) List(ctx context.Context, req *v1.ListReq) (res *v1.ListRes, err error) {
+	res = &v1.ListRes{
+		{
+			Name:        consts.InternalTools.InternalWebSearch,
+			Description: "Search the web with the built-in search of the provider",
+		},
+		{
+			Name:        consts.InternalTools.InternalImageGeneration,
+			Description: "Generate and edit images with the built-in image generation of the provider",
+		},
+	}
+	for _, tool := range llm.ListTools() {
+		*res = append(*res, &v1.ToolResponse{
+			Name:        tool.Name,
+			Description: tool.Description,
+			Parameters:  tool.Parameters,
+		})
+	}
+	return res, nil
+}
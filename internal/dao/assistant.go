@@ -0,0 +1,49 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"context"
+	"flai/internal/dao/internal"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+)
+
+// assistantDao is the data access object for the table assistant.
+// You can define custom methods on it to extend its functionality as needed.
+type assistantDao struct {
+	*internal.AssistantDao
+}
+
+var (
+	// Assistant is a globally accessible object for table assistant operations.
+	Assistant = assistantDao{internal.NewAssistantDao()}
+)
+
+// Add your custom methods and functionality below.
+
+// FetchAccessibleAssistants returns the assistants owned by the user or shared
+// with one of the user's teams, optionally limited to ids.
+func FetchAccessibleAssistants(ctx context.Context, userId string, ids ...string) ([]*entity.Assistant, error) {
+	var assistants []*entity.Assistant
+	teamIds, err := FetchUserTeamIds(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	model := Assistant.Ctx(ctx)
+	if len(teamIds) > 0 {
+		model = model.Where("user_id = ? OR team_id IN (?)", userId, teamIds)
+	} else {
+		model = model.Where(do.Assistant{UserId: userId})
+	}
+	if len(ids) > 0 {
+		model = model.Where(do.Assistant{Id: ids})
+	}
+	err = model.OrderDesc("updated_at").Scan(&assistants)
+	if err != nil {
+		return nil, err
+	}
+	return assistants, nil
+}
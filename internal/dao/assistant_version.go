@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"flai/internal/dao/internal"
+)
+
+// assistantVersionDao is the data access object for the table assistant_version.
+// You can define custom methods on it to extend its functionality as needed.
+type assistantVersionDao struct {
+	*internal.AssistantVersionDao
+}
+
+var (
+	// AssistantVersion is a globally accessible object for table assistant_version operations.
+	AssistantVersion = assistantVersionDao{internal.NewAssistantVersionDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"flai/internal/dao/internal"
+)
+
+// generationDao is the data access object for the table generation.
+// You can define custom methods on it to extend its functionality as needed.
+type generationDao struct {
+	*internal.GenerationDao
+}
+
+var (
+	// Generation is a globally accessible object for table generation operations.
+	Generation = generationDao{internal.NewGenerationDao()}
+)
+
+// Add your custom methods and functionality below.
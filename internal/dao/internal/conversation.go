@@ -21,24 +21,30 @@ type ConversationDao struct {
 
 // ConversationColumns defines and stores column names for the table conversation.
 type ConversationColumns struct {
-	Id        string //
-	UserId    string //
-	Title     string //
-	CreatedAt string //
-	UpdatedAt string //
-	DeletedAt string //
-	Icon      string //
+	Id               string //
+	UserId           string //
+	Title            string //
+	CreatedAt        string //
+	UpdatedAt        string //
+	DeletedAt        string //
+	Icon             string //
+	Settings         string //
+	AssistantId      string //
+	AssistantVersion string //
 }
 
 // conversationColumns holds the columns for the table conversation.
 var conversationColumns = ConversationColumns{
-	Id:        "id",
-	UserId:    "user_id",
-	Title:     "title",
-	CreatedAt: "created_at",
-	UpdatedAt: "updated_at",
-	DeletedAt: "deleted_at",
-	Icon:      "icon",
+	Id:               "id",
+	UserId:           "user_id",
+	Title:            "title",
+	CreatedAt:        "created_at",
+	UpdatedAt:        "updated_at",
+	DeletedAt:        "deleted_at",
+	Icon:             "icon",
+	Settings:         "settings",
+	AssistantId:      "assistant_id",
+	AssistantVersion: "assistant_version",
 }
 
 // NewConversationDao creates and returns a new DAO object for table data access.
@@ -32,6 +32,7 @@ type ProviderColumns struct {
 	UpdatedAt    string //
 	DeletedAt    string //
 	Logo         string //
+	KeySelection string //
 }
 
 // providerColumns holds the columns for the table provider.
@@ -47,6 +48,7 @@ var providerColumns = ProviderColumns{
 	UpdatedAt:    "updated_at",
 	DeletedAt:    "deleted_at",
 	Logo:         "logo",
+	KeySelection: "key_selection",
 }
 
 // NewProviderDao creates and returns a new DAO object for table data access.
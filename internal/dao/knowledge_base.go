@@ -0,0 +1,51 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"context"
+	"flai/internal/dao/internal"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+)
+
+// knowledgeBaseDao is the data access object for the table knowledge_base.
+// You can define custom methods on it to extend its functionality as needed.
+type knowledgeBaseDao struct {
+	*internal.KnowledgeBaseDao
+}
+
+var (
+	// KnowledgeBase is a globally accessible object for table knowledge_base operations.
+	KnowledgeBase = knowledgeBaseDao{internal.NewKnowledgeBaseDao()}
+)
+
+// Add your custom methods and functionality below.
+
+// FetchAccessibleKnowledgeBases returns the personal knowledge bases of the
+// user and the knowledge bases of the user's teams. Team knowledge bases are
+// only matched by membership, so a creator who left the team loses access.
+// When ids are given only those are returned.
+func FetchAccessibleKnowledgeBases(ctx context.Context, userId string, ids ...string) ([]*entity.KnowledgeBase, error) {
+	var knowledgeBases []*entity.KnowledgeBase
+	teamIds, err := FetchUserTeamIds(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	model := KnowledgeBase.Ctx(ctx)
+	if len(teamIds) > 0 {
+		model = model.Where("(team_id IS NULL AND user_id = ?) OR team_id IN (?)", userId, teamIds)
+	} else {
+		model = model.Where("team_id IS NULL").Where(do.KnowledgeBase{UserId: userId})
+	}
+	if len(ids) > 0 {
+		model = model.Where(do.KnowledgeBase{Id: ids})
+	}
+	err = model.OrderDesc("created_at").Scan(&knowledgeBases)
+	if err != nil {
+		return nil, err
+	}
+	return knowledgeBases, nil
+}
@@ -0,0 +1,71 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"context"
+	"flai/internal/dao/internal"
+	"flai/internal/model"
+	"strconv"
+	"strings"
+)
+
+// knowledgeChunkDao is the data access object for the table knowledge_chunk.
+// You can define custom methods on it to extend its functionality as needed.
+type knowledgeChunkDao struct {
+	*internal.KnowledgeChunkDao
+}
+
+var (
+	// KnowledgeChunk is a globally accessible object for table knowledge_chunk operations.
+	KnowledgeChunk = knowledgeChunkDao{internal.NewKnowledgeChunkDao()}
+)
+
+// Add your custom methods and functionality below.
+
+// SearchKnowledgeChunks returns the chunks of the knowledge bases closest to
+// the query embedding by cosine distance.
+func SearchKnowledgeChunks(ctx context.Context, knowledgeBaseIds []string, embedding []float32, limit int) ([]*model.KnowledgeHit, error) {
+	hits := make([]*model.KnowledgeHit, 0, limit)
+	if len(knowledgeBaseIds) == 0 || len(embedding) == 0 {
+		return hits, nil
+	}
+	vector := VectorLiteral(embedding)
+	result, err := KnowledgeChunk.DB().GetAll(ctx, `
+SELECT kc.id::text                             AS chunk_id,
+       kc.knowledge_base_id::text              AS knowledge_base_id,
+       kc.document_id::text                    AS document_id,
+       kd.file_name                            AS file_name,
+       kc.chunk_index                          AS chunk_index,
+       kc.content                              AS content,
+       1 - (kc.embedding <=> ?::vector)        AS score
+FROM knowledge_chunk kc
+         JOIN knowledge_document kd ON kd.id = kc.document_id
+WHERE kc.knowledge_base_id IN (?)
+  AND vector_dims(kc.embedding) = ?
+ORDER BY kc.embedding <=> ?::vector
+LIMIT ?`, vector, knowledgeBaseIds, len(embedding), vector, limit)
+	if err != nil {
+		return nil, err
+	}
+	if err = result.Structs(&hits); err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
+
+// VectorLiteral formats an embedding as a pgvector text literal.
+func VectorLiteral(embedding []float32) string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for i, value := range embedding {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(strconv.FormatFloat(float64(value), 'f', -1, 32))
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
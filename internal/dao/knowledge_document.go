@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"flai/internal/dao/internal"
+)
+
+// knowledgeDocumentDao is the data access object for the table knowledge_document.
+// You can define custom methods on it to extend its functionality as needed.
+type knowledgeDocumentDao struct {
+	*internal.KnowledgeDocumentDao
+}
+
+var (
+	// KnowledgeDocument is a globally accessible object for table knowledge_document operations.
+	KnowledgeDocument = knowledgeDocumentDao{internal.NewKnowledgeDocumentDao()}
+)
+
+// Add your custom methods and functionality below.
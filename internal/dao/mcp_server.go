@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"flai/internal/dao/internal"
+)
+
+// mcpServerDao is the data access object for the table mcp_server.
+// You can define custom methods on it to extend its functionality as needed.
+type mcpServerDao struct {
+	*internal.McpServerDao
+}
+
+var (
+	// McpServer is a globally accessible object for table mcp_server operations.
+	McpServer = mcpServerDao{internal.NewMcpServerDao()}
+)
+
+// Add your custom methods and functionality below.
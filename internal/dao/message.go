@@ -35,3 +35,22 @@ func FetchMessageHistory(ctx context.Context, messagePath []string) ([]*entity.M
 	}
 	return nil, nil
 }
+
+// FetchConversationMessages returns all messages of the conversations in
+// creation order.
+func FetchConversationMessages(ctx context.Context, conversationIds ...string) ([]*entity.Message, error) {
+	var messages []*entity.Message
+	if len(conversationIds) == 0 {
+		return messages, nil
+	}
+	err := Message.Ctx(ctx).Where(do.Message{
+		ConversationId: conversationIds,
+	}).
+		WhereNull("deleted_at").
+		OrderAsc("created_at").
+		Scan(&messages)
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
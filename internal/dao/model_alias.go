@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"flai/internal/dao/internal"
+)
+
+// modelAliasDao is the data access object for the table model_alias.
+// You can define custom methods on it to extend its functionality as needed.
+type modelAliasDao struct {
+	*internal.ModelAliasDao
+}
+
+var (
+	// ModelAlias is a globally accessible object for table model_alias operations.
+	ModelAlias = modelAliasDao{internal.NewModelAliasDao()}
+)
+
+// Add your custom methods and functionality below.
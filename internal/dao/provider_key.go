@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"flai/internal/dao/internal"
+)
+
+// providerKeyDao is the data access object for the table provider_key.
+// You can define custom methods on it to extend its functionality as needed.
+type providerKeyDao struct {
+	*internal.ProviderKeyDao
+}
+
+var (
+	// ProviderKey is a globally accessible object for table provider_key operations.
+	ProviderKey = providerKeyDao{internal.NewProviderKeyDao()}
+)
+
+// Add your custom methods and functionality below.
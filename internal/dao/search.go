@@ -0,0 +1,91 @@
+package dao
+
+import (
+	"context"
+	"flai/internal/model"
+	"html"
+	"strings"
+)
+
+// searchHitsSql matches messages and conversation titles of one user. The
+// to_tsvector expressions must stay identical to the ones used by the GIN
+// indexes in manifest/sql/001_search.sql, otherwise the indexes are skipped.
+const searchHitsSql = `
+SELECT m.conversation_id::text                                                  AS conversation_id,
+       c.title                                                                  AS conversation_title,
+       m.id::text                                                               AS message_id,
+       m.role                                                                   AS role,
+       message_search_text(m.content::jsonb)                                    AS body,
+       ts_rank(to_tsvector('simple', message_search_text(m.content::jsonb)), q) AS rank,
+       m.created_at                                                             AS created_at
+FROM message m
+         JOIN conversation c ON c.id = m.conversation_id,
+     websearch_to_tsquery('simple', ?) q
+WHERE c.user_id = ?
+  AND c.deleted_at IS NULL
+  AND m.deleted_at IS NULL
+  AND to_tsvector('simple', message_search_text(m.content::jsonb)) @@ q
+UNION ALL
+SELECT c.id::text                              AS conversation_id,
+       c.title                                 AS conversation_title,
+       NULL                                    AS message_id,
+       NULL                                    AS role,
+       c.title                                 AS body,
+       ts_rank(to_tsvector('simple', c.title), q) AS rank,
+       c.created_at                            AS created_at
+FROM conversation c,
+     websearch_to_tsquery('simple', ?) q
+WHERE c.user_id = ?
+  AND c.deleted_at IS NULL
+  AND to_tsvector('simple', c.title) @@ q
+`
+
+const (
+	highlightStart   = "<mark>"
+	highlightStop    = "</mark>"
+	highlightOptions = "StartSel=" + highlightStart + ", StopSel=" + highlightStop + ", MaxWords=35, MinWords=15, MaxFragments=2"
+)
+
+// SearchMessages runs a ranked full-text search over the user's messages and
+// conversation titles and returns one page of hits with highlighted snippets.
+func SearchMessages(ctx context.Context, userId string, keyword string, current int, size int) ([]*model.SearchHit, int, error) {
+	args := []any{keyword, userId, keyword, userId}
+
+	total, err := Message.DB().GetCount(ctx, "SELECT count(*) FROM ("+searchHitsSql+") hits", args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []*model.SearchHit{}, 0, nil
+	}
+
+	pageSql := `
+SELECT conversation_id, conversation_title, message_id, role, rank, created_at,
+       ts_headline('simple', body, websearch_to_tsquery('simple', ?), ?) AS snippet
+FROM (` + searchHitsSql + `) hits
+ORDER BY rank DESC, created_at DESC
+LIMIT ? OFFSET ?`
+	pageArgs := append([]any{keyword, highlightOptions}, args...)
+	pageArgs = append(pageArgs, size, (current-1)*size)
+
+	result, err := Message.DB().GetAll(ctx, pageSql, pageArgs...)
+	if err != nil {
+		return nil, 0, err
+	}
+	hits := make([]*model.SearchHit, 0, len(result))
+	if err = result.Structs(&hits); err != nil {
+		return nil, 0, err
+	}
+	for _, hit := range hits {
+		hit.Snippet = escapeSnippet(hit.Snippet)
+	}
+	return hits, total, nil
+}
+
+// escapeSnippet HTML-escapes the stored text while keeping the highlight tags
+// added by ts_headline, so the client can render snippets as HTML safely.
+func escapeSnippet(snippet string) string {
+	escaped := html.EscapeString(snippet)
+	escaped = strings.ReplaceAll(escaped, html.EscapeString(highlightStart), highlightStart)
+	return strings.ReplaceAll(escaped, html.EscapeString(highlightStop), highlightStop)
+}
@@ -0,0 +1,43 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"context"
+	"flai/internal/dao/internal"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// shareDao is the data access object for the table share.
+// You can define custom methods on it to extend its functionality as needed.
+type shareDao struct {
+	*internal.ShareDao
+}
+
+var (
+	// Share is a globally accessible object for table share operations.
+	Share = shareDao{internal.NewShareDao()}
+)
+
+// Add your custom methods and functionality below.
+
+// GetActiveShare returns the share link with the given id, or nil when it
+// does not exist, was revoked or has expired.
+func GetActiveShare(ctx context.Context, id string) (*entity.Share, error) {
+	var share *entity.Share
+	err := Share.Ctx(ctx).Where(do.Share{
+		Id: id,
+	}).
+		WhereNull("revoked_at").
+		Where("expires_at IS NULL OR expires_at > ?", gtime.Now()).
+		Scan(&share)
+	if err != nil {
+		return nil, err
+	}
+	return share, nil
+}
@@ -0,0 +1,53 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"context"
+	"flai/internal/dao/internal"
+	"flai/internal/model/do"
+)
+
+// teamDao is the data access object for the table team.
+// You can define custom methods on it to extend its functionality as needed.
+type teamDao struct {
+	*internal.TeamDao
+}
+
+var (
+	// Team is a globally accessible object for table team operations.
+	Team = teamDao{internal.NewTeamDao()}
+)
+
+// Add your custom methods and functionality below.
+
+// FetchUserTeamIds returns the ids of the teams the user is a member of.
+func FetchUserTeamIds(ctx context.Context, userId string) ([]string, error) {
+	values, err := TeamMember.Ctx(ctx).
+		Fields("team_id").
+		Where(do.TeamMember{UserId: userId}).
+		Array()
+	if err != nil {
+		return nil, err
+	}
+	teamIds := make([]string, 0, len(values))
+	for _, value := range values {
+		teamIds = append(teamIds, value.String())
+	}
+	return teamIds, nil
+}
+
+// GetTeamRole returns the role of the user in the team, or an empty string
+// when the user is not a member.
+func GetTeamRole(ctx context.Context, teamId string, userId string) (string, error) {
+	value, err := TeamMember.Ctx(ctx).
+		Fields("role").
+		Where(do.TeamMember{TeamId: teamId, UserId: userId}).
+		Value()
+	if err != nil {
+		return "", err
+	}
+	return value.String(), nil
+}
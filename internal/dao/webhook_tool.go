@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"flai/internal/dao/internal"
+)
+
+// webhookToolDao is the data access object for the table webhook_tool.
+// You can define custom methods on it to extend its functionality as needed.
+type webhookToolDao struct {
+	*internal.WebhookToolDao
+}
+
+var (
+	// WebhookTool is a globally accessible object for table webhook_tool operations.
+	WebhookTool = webhookToolDao{internal.NewWebhookToolDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -0,0 +1,295 @@
+package assistant
+
+import (
+	"context"
+	"encoding/json"
+	"flai/internal/consts"
+	"flai/internal/dao"
+	"flai/internal/logic"
+	"flai/internal/logic/llm"
+	"flai/internal/model"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+	"slices"
+	"strings"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/google/uuid"
+)
+
+const maxNameLength = 100
+
+// Check validates a definition for an assistant of the user, shared with the
+// team when teamId is set. Knowledge bases of a team assistant have to belong
+// to the same team so every member can search them.
+func Check(ctx context.Context, userId string, teamId string, definition *model.AssistantDefinition) error {
+	definition.Name = strings.TrimSpace(definition.Name)
+	if definition.Name == "" {
+		return gerror.New("name cannot be empty")
+	}
+	if len([]rune(definition.Name)) > maxNameLength {
+		return gerror.Newf("name exceeds %d characters", maxNameLength)
+	}
+
+	if (definition.ProviderId == "") != (definition.ModelName == "") {
+		return gerror.New("provider and model have to be set together")
+	}
+	if definition.ProviderId == consts.AliasProviderId {
+		if logic.GetModelAlias(definition.ModelName) == nil {
+			return gerror.New("invalid model name")
+		}
+		// The model is only known per request
+		if err := llm.CheckSettings(definition.Settings); err != nil {
+			return err
+		}
+	} else if definition.ProviderId != "" {
+		providerInfo := logic.ProviderMap[definition.ProviderId]
+		if providerInfo == nil {
+			return gerror.New("invalid provider ID")
+		}
+		modelConfig := providerInfo.ModelIdMap[definition.ModelName]
+		if modelConfig == nil {
+			return gerror.New("invalid model name")
+		}
+		if err := llm.CheckModelSettings(providerInfo, modelConfig, definition.Settings); err != nil {
+			return err
+		}
+	} else if err := llm.CheckSettings(definition.Settings); err != nil {
+		return err
+	}
+
+	if err := llm.CheckTools(definition.Tools); err != nil {
+		return err
+	}
+
+	knowledgeBaseIds := slices.Compact(slices.Sorted(slices.Values(definition.KnowledgeBases)))
+	if len(knowledgeBaseIds) > 0 {
+		knowledgeBases, err := dao.FetchAccessibleKnowledgeBases(ctx, userId, knowledgeBaseIds...)
+		if err != nil {
+			return err
+		}
+		if len(knowledgeBases) != len(knowledgeBaseIds) {
+			return gerror.New("knowledge base not found")
+		}
+		for _, knowledgeBase := range knowledgeBases {
+			if teamId != "" && knowledgeBase.TeamId != teamId {
+				return gerror.Newf("knowledge base %s is not shared with the team", knowledgeBase.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// CanManage reports whether the user may change or delete the assistant. That
+// is its author or an owner of its team.
+func CanManage(ctx context.Context, assistant *entity.Assistant, userId string) (bool, error) {
+	if assistant.UserId == userId {
+		return true, nil
+	}
+	if assistant.TeamId == "" {
+		return false, nil
+	}
+	role, err := dao.GetTeamRole(ctx, assistant.TeamId, userId)
+	if err != nil {
+		return false, err
+	}
+	return role == consts.TeamRole.Owner, nil
+}
+
+// Create stores a new assistant with its first version.
+func Create(ctx context.Context, userId string, teamId string, definition *model.AssistantDefinition) (*entity.Assistant, error) {
+	assistant := &entity.Assistant{
+		Id:      uuid.New().String(),
+		UserId:  userId,
+		TeamId:  teamId,
+		Version: 1,
+	}
+	setDefinition(assistant, definition)
+	err := dao.Assistant.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		if _, err := dao.Assistant.Ctx(ctx).Data(assistant).OmitEmptyData().Insert(); err != nil {
+			return err
+		}
+		return insertVersion(ctx, assistant)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return assistant, nil
+}
+
+// Update replaces the definition of the assistant and adds it as a new
+// version. Conversations started earlier keep their version.
+func Update(ctx context.Context, id string, definition *model.AssistantDefinition) (*entity.Assistant, error) {
+	assistant := &entity.Assistant{}
+	err := dao.Assistant.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		err := dao.Assistant.Ctx(ctx).Where(do.Assistant{Id: id}).LockUpdate().Scan(assistant)
+		if err != nil {
+			return err
+		}
+		assistant.Version++
+		setDefinition(assistant, definition)
+		_, err = dao.Assistant.Ctx(ctx).Data(do.Assistant{
+			Version:        assistant.Version,
+			Name:           assistant.Name,
+			Icon:           assistant.Icon,
+			Description:    assistant.Description,
+			ProviderId:     assistant.ProviderId,
+			ModelName:      assistant.ModelName,
+			Tools:          assistant.Tools,
+			KnowledgeBases: assistant.KnowledgeBases,
+			Settings:       assistant.Settings,
+		}).Where(do.Assistant{Id: id}).Update()
+		if err != nil {
+			return err
+		}
+		return insertVersion(ctx, assistant)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return assistant, nil
+}
+
+// Delete removes the assistant. Its versions are kept for the conversations
+// that were started from it.
+func Delete(ctx context.Context, id string) error {
+	_, err := dao.Assistant.Ctx(ctx).Where(do.Assistant{Id: id}).Delete()
+	return err
+}
+
+// Definition decodes the current definition of an assistant.
+func Definition(assistant *entity.Assistant) (*model.AssistantDefinition, error) {
+	definition := &model.AssistantDefinition{
+		Name:        assistant.Name,
+		Icon:        assistant.Icon,
+		Description: assistant.Description,
+		ProviderId:  assistant.ProviderId,
+		ModelName:   assistant.ModelName,
+	}
+	return definition, decodeColumns(definition, assistant.Tools, assistant.KnowledgeBases, assistant.Settings)
+}
+
+// FetchVersion returns the definition of one version of an assistant, also
+// when the assistant has been deleted since.
+func FetchVersion(ctx context.Context, id string, version int) (*model.AssistantDefinition, error) {
+	var row *entity.AssistantVersion
+	err := dao.AssistantVersion.Ctx(ctx).Where(do.AssistantVersion{
+		AssistantId: id,
+		Version:     version,
+	}).Scan(&row)
+	if err != nil {
+		return nil, err
+	}
+	if row == nil {
+		return nil, gerror.Newf("version %d of assistant not found", version)
+	}
+	definition := &model.AssistantDefinition{
+		Name:        row.Name,
+		Icon:        row.Icon,
+		Description: row.Description,
+		ProviderId:  row.ProviderId,
+		ModelName:   row.ModelName,
+	}
+	return definition, decodeColumns(definition, row.Tools, row.KnowledgeBases, row.Settings)
+}
+
+// DropUnavailable removes the tools that are no longer registered and the
+// knowledge bases the user can no longer access from a definition, so
+// conversations pinned to an older version keep working.
+func DropUnavailable(ctx context.Context, userId string, definition *model.AssistantDefinition) error {
+	definition.Tools = slices.DeleteFunc(definition.Tools, func(name string) bool {
+		return llm.CheckTools([]string{name}) != nil
+	})
+	if len(definition.KnowledgeBases) == 0 {
+		return nil
+	}
+	knowledgeBases, err := dao.FetchAccessibleKnowledgeBases(ctx, userId, definition.KnowledgeBases...)
+	if err != nil {
+		return err
+	}
+	definition.KnowledgeBases = slices.DeleteFunc(definition.KnowledgeBases, func(id string) bool {
+		return !slices.ContainsFunc(knowledgeBases, func(knowledgeBase *entity.KnowledgeBase) bool {
+			return knowledgeBase.Id == id
+		})
+	})
+	return nil
+}
+
+// StartConversation creates a conversation for the user from the current
+// version of the assistant. The settings are copied, so later changes to the
+// conversation do not touch the assistant.
+func StartConversation(ctx context.Context, userId string, assistant *entity.Assistant) (*entity.Conversation, error) {
+	definition, err := Definition(assistant)
+	if err != nil {
+		return nil, err
+	}
+	conversation := &entity.Conversation{
+		Id:               uuid.New().String(),
+		UserId:           userId,
+		Title:            "Untitled",
+		Icon:             assistant.Icon,
+		Settings:         definition.Settings.String(),
+		AssistantId:      assistant.Id,
+		AssistantVersion: assistant.Version,
+	}
+	if _, err = dao.Conversation.Ctx(ctx).Data(conversation).OmitEmptyData().Insert(); err != nil {
+		return nil, err
+	}
+	return conversation, nil
+}
+
+func setDefinition(assistant *entity.Assistant, definition *model.AssistantDefinition) {
+	assistant.Name = definition.Name
+	assistant.Icon = definition.Icon
+	assistant.Description = definition.Description
+	assistant.ProviderId = definition.ProviderId
+	assistant.ModelName = definition.ModelName
+	assistant.Tools = jsonList(definition.Tools)
+	assistant.KnowledgeBases = jsonList(definition.KnowledgeBases)
+	assistant.Settings = definition.Settings.String()
+}
+
+func insertVersion(ctx context.Context, assistant *entity.Assistant) error {
+	_, err := dao.AssistantVersion.Ctx(ctx).Data(do.AssistantVersion{
+		AssistantId:    assistant.Id,
+		Version:        assistant.Version,
+		Name:           assistant.Name,
+		Icon:           assistant.Icon,
+		Description:    assistant.Description,
+		ProviderId:     assistant.ProviderId,
+		ModelName:      assistant.ModelName,
+		Tools:          assistant.Tools,
+		KnowledgeBases: assistant.KnowledgeBases,
+		Settings:       assistant.Settings,
+	}).Insert()
+	return err
+}
+
+func decodeColumns(definition *model.AssistantDefinition, tools string, knowledgeBases string, settings string) error {
+	if tools != "" {
+		if err := json.Unmarshal([]byte(tools), &definition.Tools); err != nil {
+			return err
+		}
+	}
+	if knowledgeBases != "" {
+		if err := json.Unmarshal([]byte(knowledgeBases), &definition.KnowledgeBases); err != nil {
+			return err
+		}
+	}
+	var err error
+	definition.Settings, err = model.ParseChatSettings(settings)
+	return err
+}
+
+// jsonList encodes a list for a jsonb column.
+func jsonList(list []string) string {
+	if len(list) == 0 {
+		return "[]"
+	}
+	data, err := json.Marshal(list)
+	if err != nil {
+		return "[]"
+	}
+	return string(data)
+}
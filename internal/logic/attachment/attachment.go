@@ -0,0 +1,250 @@
+package attachment
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"flai/internal/consts"
+	"flai/internal/dao"
+	"flai/internal/logic"
+	"flai/internal/logic/extract"
+	"flai/internal/logic/storage"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultMaxSize   = 20 << 20
+	defaultUserQuota = 1 << 30
+)
+
+// Kinds of attachments, derived from the mime type
+const (
+	KindImage = "image"
+	KindPDF   = "pdf"
+	KindFile  = "file"
+)
+
+// MaxSize returns the largest accepted upload in bytes.
+func MaxSize(ctx context.Context) int64 {
+	return g.Cfg().MustGet(ctx, "attachment.maxSize", defaultMaxSize).Int64()
+}
+
+// UserQuota returns the total bytes a user may store, zero means unlimited.
+func UserQuota(ctx context.Context) int64 {
+	return g.Cfg().MustGet(ctx, "attachment.userQuota", defaultUserQuota).Int64()
+}
+
+// Usage returns the bytes currently stored by the user. Deduplicated files
+// still count against every user that uploaded them.
+func Usage(ctx context.Context, userId string) (int64, error) {
+	usage, err := dao.Attachment.Ctx(ctx).Where(do.Attachment{UserId: userId}).Sum("size")
+	if err != nil {
+		return 0, err
+	}
+	return int64(usage), nil
+}
+
+// storageKey addresses blobs by their content so identical uploads are only
+// stored once.
+func storageKey(data []byte) string {
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+	return "sha256/" + digest[:2] + "/" + digest
+}
+
+// Save stores an uploaded file for the user. The attachment is not bound to a
+// message until the message is created.
+func Save(ctx context.Context, userId string, fileName string, reader io.Reader) (*entity.Attachment, error) {
+	maxSize := MaxSize(ctx)
+	data, err := io.ReadAll(io.LimitReader(reader, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, gerror.Newf("file exceeds the maximum size of %d bytes", maxSize)
+	}
+	if len(data) == 0 {
+		return nil, gerror.New("file is empty")
+	}
+	return SaveBytes(ctx, userId, fileName, DetectMimeType(fileName, data), data)
+}
+
+// SaveBytes stores data for the user after checking the storage quota.
+func SaveBytes(ctx context.Context, userId string, fileName string, mimeType string, data []byte) (*entity.Attachment, error) {
+	if quota := UserQuota(ctx); quota > 0 {
+		usage, err := Usage(ctx, userId)
+		if err != nil {
+			return nil, err
+		}
+		if usage+int64(len(data)) > quota {
+			return nil, gerror.Newf("storage quota of %d bytes exceeded", quota)
+		}
+	}
+
+	attachment := &entity.Attachment{
+		Id:       uuid.New().String(),
+		UserId:   userId,
+		FileName: filepath.Base(fileName),
+		MimeType: mimeType,
+		Size:     int64(len(data)),
+		Path:     storageKey(data),
+	}
+	// Identical content is stored once, the lock keeps Purge from deleting the
+	// object between the check and the insert
+	store := storage.Default(ctx)
+	err := dao.Attachment.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		if err := lockKey(ctx, attachment.Path); err != nil {
+			return err
+		}
+		exists, err := store.Exists(ctx, attachment.Path)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			if err = store.Put(ctx, attachment.Path, data, attachment.MimeType); err != nil {
+				return err
+			}
+		}
+		_, err = dao.Attachment.Ctx(ctx).Data(attachment).OmitEmpty().Insert()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// Read returns the stored bytes of an attachment.
+func Read(ctx context.Context, attachment *entity.Attachment) ([]byte, error) {
+	return storage.Default(ctx).Get(ctx, attachment.Path)
+}
+
+// ErrNotFound is returned by Load for attachments that were deleted.
+var ErrNotFound = gerror.New("attachment not found")
+
+// Load fetches an attachment of the user and its bytes by id. Attachments of
+// other users are reported as not found.
+func Load(ctx context.Context, userId string, id string) (*entity.Attachment, []byte, error) {
+	var attachment *entity.Attachment
+	err := dao.Attachment.Ctx(ctx).Where(do.Attachment{
+		Id:     id,
+		UserId: userId,
+	}).Scan(&attachment)
+	if err != nil {
+		return nil, nil, err
+	}
+	if attachment == nil {
+		return nil, nil, gerror.Wrapf(ErrNotFound, "attachment %s", id)
+	}
+	data, err := Read(ctx, attachment)
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, data, nil
+}
+
+// FetchUnbound returns the user's attachments that are not yet sent with
+// another message. Attachments already bound to messageId are accepted so a
+// retried request is idempotent.
+func FetchUnbound(ctx context.Context, userId string, ids []string, messageId string) ([]*entity.Attachment, error) {
+	var attachments []*entity.Attachment
+	if len(ids) == 0 {
+		return attachments, nil
+	}
+	err := dao.Attachment.Ctx(ctx).Where(do.Attachment{
+		Id:     ids,
+		UserId: userId,
+	}).
+		Where("message_id IS NULL OR message_id = ?", messageId).
+		Scan(&attachments)
+	if err != nil {
+		return nil, err
+	}
+	if len(attachments) != len(ids) {
+		return nil, gerror.New("attachment not found")
+	}
+	// Keep the order chosen by the client
+	slices.SortFunc(attachments, func(a, b *entity.Attachment) int {
+		return slices.Index(ids, a.Id) - slices.Index(ids, b.Id)
+	})
+	return attachments, nil
+}
+
+// Bind attaches the uploads to the message they were sent with.
+func Bind(ctx context.Context, attachments []*entity.Attachment, messageId string) error {
+	if len(attachments) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(attachments))
+	for _, attachment := range attachments {
+		ids = append(ids, attachment.Id)
+	}
+	_, err := dao.Attachment.Ctx(ctx).Data(do.Attachment{
+		MessageId: messageId,
+	}).Where(do.Attachment{
+		Id: ids,
+	}).Update()
+	return err
+}
+
+// DetectMimeType sniffs the content and falls back to the file extension for
+// text based formats that sniffing cannot tell apart.
+func DetectMimeType(fileName string, data []byte) string {
+	detected := http.DetectContentType(data)
+	if detected != "application/octet-stream" && !strings.HasPrefix(detected, "text/plain") && detected != "application/zip" {
+		return strings.Split(detected, ";")[0]
+	}
+	if byExt := mime.TypeByExtension(strings.ToLower(filepath.Ext(fileName))); byExt != "" {
+		return strings.Split(byExt, ";")[0]
+	}
+	return strings.Split(detected, ";")[0]
+}
+
+// Kind groups mime types by how providers accept them.
+func Kind(mimeType string) string {
+	switch {
+	case strings.HasPrefix(mimeType, "image/"):
+		return KindImage
+	case mimeType == "application/pdf":
+		return KindPDF
+	default:
+		return KindFile
+	}
+}
+
+// SendNatively reports whether the model takes the attachment as input
+// according to its modalities.
+func SendNatively(modelConfig *logic.ModelConfig, attachment *entity.Attachment) bool {
+	if !modelConfig.Attachment {
+		return false
+	}
+	switch Kind(attachment.MimeType) {
+	case KindImage:
+		return slices.Contains(modelConfig.Modalities.Input, consts.Modality.Image)
+	case KindPDF:
+		return slices.Contains(modelConfig.Modalities.Input, consts.Modality.PDF)
+	default:
+		return slices.Contains(modelConfig.Modalities.Input, consts.Modality.File)
+	}
+}
+
+// CheckModel returns an error when the model can neither take the attachment
+// natively nor read the text extracted from it.
+func CheckModel(modelConfig *logic.ModelConfig, attachment *entity.Attachment) error {
+	if SendNatively(modelConfig, attachment) || extract.Supported(attachment.MimeType, attachment.FileName) {
+		return nil
+	}
+	return gerror.Newf("model %s does not accept %s files", modelConfig.Name, attachment.MimeType)
+}
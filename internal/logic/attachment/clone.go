@@ -0,0 +1,99 @@
+package attachment
+
+import (
+	"context"
+	"encoding/json"
+	"flai/internal/consts"
+	"flai/internal/dao"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+
+	"github.com/google/uuid"
+)
+
+// block is a stored content block of a message. Only the id in the data of
+// file blocks is rewritten, everything else is kept as it is.
+type block struct {
+	Type      string          `json:"type"`
+	Data      json.RawMessage `json:"data"`
+	Signature string          `json:"signature,omitempty"`
+}
+
+// CopyToClones gives every message cloned from a source message its own
+// copies of the attachments and images of the source, owned by the user, and
+// points its content blocks at them. The copies share the stored blob, so it
+// stays alive when the source is deleted. Only attachments bound to the
+// source message are copied; blocks referring to anything else are kept and
+// treated as missing when the conversation continues.
+func CopyToClones(ctx context.Context, userId string, sources []*entity.Message, clones []*entity.Message) error {
+	for i, source := range sources {
+		clone := clones[i]
+		var blocks []block
+		if err := json.Unmarshal([]byte(clone.Content), &blocks); err != nil {
+			continue
+		}
+		changed := false
+		for j, content := range blocks {
+			if content.Type != consts.MessageType.Attachment && content.Type != consts.MessageType.Image {
+				continue
+			}
+			var data map[string]any
+			if err := json.Unmarshal(content.Data, &data); err != nil {
+				continue
+			}
+			id, _ := data["id"].(string)
+			if id == "" {
+				continue
+			}
+			var file *entity.Attachment
+			err := dao.Attachment.Ctx(ctx).Where(do.Attachment{
+				Id:        id,
+				MessageId: source.Id,
+			}).Scan(&file)
+			if err != nil {
+				return err
+			}
+			if file == nil {
+				continue
+			}
+			// The source may have been purged while waiting for the lock
+			if err = lockKey(ctx, file.Path); err != nil {
+				return err
+			}
+			count, err := dao.Attachment.Ctx(ctx).Where(do.Attachment{Id: file.Id}).Count()
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				continue
+			}
+
+			copied := &entity.Attachment{
+				Id:        uuid.New().String(),
+				UserId:    userId,
+				MessageId: clone.Id,
+				FileName:  file.FileName,
+				MimeType:  file.MimeType,
+				Size:      file.Size,
+				Path:      file.Path,
+			}
+			if _, err = dao.Attachment.Ctx(ctx).Data(copied).OmitEmpty().Insert(); err != nil {
+				return err
+			}
+			data["id"] = copied.Id
+			if blocks[j].Data, err = json.Marshal(data); err != nil {
+				return err
+			}
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+		contentByte, err := json.Marshal(blocks)
+		if err != nil {
+			return err
+		}
+		clone.Content = string(contentByte)
+	}
+	return nil
+}
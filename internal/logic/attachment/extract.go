@@ -0,0 +1,38 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"flai/internal/logic/extract"
+	"flai/internal/logic/storage"
+	"flai/internal/model/entity"
+)
+
+// extractedTextKey stores the extracted text next to the content-addressed
+// blob, so it is shared by deduplicated uploads as well.
+func extractedTextKey(key string) string {
+	return key + ".txt"
+}
+
+// ExtractText returns the text of a document attachment, extracting and
+// caching it on first use.
+func ExtractText(ctx context.Context, attachment *entity.Attachment, data []byte) (string, error) {
+	store := storage.Default(ctx)
+	key := extractedTextKey(attachment.Path)
+	cached, err := store.Get(ctx, key)
+	if err == nil {
+		return string(cached), nil
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return "", err
+	}
+
+	text, err := extract.Text(attachment.MimeType, attachment.FileName, data)
+	if err != nil {
+		return "", err
+	}
+	if err = store.Put(ctx, key, []byte(text), "text/plain; charset=utf-8"); err != nil {
+		return "", err
+	}
+	return text, nil
+}
@@ -0,0 +1,112 @@
+package attachment
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/logic/storage"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// Purge removes attachment rows and deletes every blob that is no longer
+// referenced by another attachment.
+func Purge(ctx context.Context, attachments []*entity.Attachment) error {
+	if len(attachments) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(attachments))
+	keys := make(map[string]bool)
+	for _, attachment := range attachments {
+		ids = append(ids, attachment.Id)
+		keys[attachment.Path] = true
+	}
+	if _, err := dao.Attachment.Ctx(ctx).Delete(do.Attachment{Id: ids}); err != nil {
+		return err
+	}
+
+	store := storage.Default(ctx)
+	for key := range keys {
+		err := dao.Attachment.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+			if err := lockKey(ctx, key); err != nil {
+				return err
+			}
+			count, err := dao.Attachment.Ctx(ctx).Where(do.Attachment{Path: key}).Count()
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return nil
+			}
+			if err = store.Delete(ctx, key); err != nil {
+				return err
+			}
+			return store.Delete(ctx, extractedTextKey(key))
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lockKey serializes new references to a stored object with the check whether
+// it is still referenced, so an upload cannot reuse an object that is being
+// deleted. The lock is held until the transaction in ctx ends.
+func lockKey(ctx context.Context, key string) error {
+	_, err := dao.Attachment.DB().Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext(?))", key)
+	return err
+}
+
+// PurgeByMessages removes the attachments sent with the messages.
+func PurgeByMessages(ctx context.Context, messageIds ...string) error {
+	if len(messageIds) == 0 {
+		return nil
+	}
+	var attachments []*entity.Attachment
+	err := dao.Attachment.Ctx(ctx).Where(do.Attachment{MessageId: messageIds}).Scan(&attachments)
+	if err != nil {
+		return err
+	}
+	return Purge(ctx, attachments)
+}
+
+// PurgeByConversation removes the attachments of every message in the
+// conversation, including messages that were already deleted.
+func PurgeByConversation(ctx context.Context, conversationId string) error {
+	messageIds, err := dao.Message.Ctx(ctx).Unscoped().Where(do.Message{
+		ConversationId: conversationId,
+	}).Array("id")
+	if err != nil {
+		return err
+	}
+	ids := make([]string, 0, len(messageIds))
+	for _, id := range messageIds {
+		ids = append(ids, id.String())
+	}
+	return PurgeByMessages(ctx, ids...)
+}
+
+// PurgeOrphans removes uploads that were never sent with a message.
+func PurgeOrphans(ctx context.Context, olderThan time.Duration) {
+	var attachments []*entity.Attachment
+	err := dao.Attachment.Ctx(ctx).
+		WhereNull("message_id").
+		WhereLT("created_at", gtime.Now().Add(-olderThan)).
+		Scan(&attachments)
+	if err != nil {
+		g.Log().Errorf(ctx, "Failed to fetch orphan attachments: %v", err)
+		return
+	}
+	if err = Purge(ctx, attachments); err != nil {
+		g.Log().Errorf(ctx, "Failed to purge orphan attachments: %v", err)
+		return
+	}
+	if len(attachments) > 0 {
+		g.Log().Infof(ctx, "Purged %d orphan attachments", len(attachments))
+	}
+}
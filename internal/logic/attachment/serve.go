@@ -0,0 +1,36 @@
+package attachment
+
+import (
+	"flai/internal/model/entity"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/gogf/gf/v2/net/ghttp"
+)
+
+// inlineTypes are shown by browsers without running anything on the origin
+// of the app.
+var inlineTypes = []string{
+	"image/png",
+	"image/jpeg",
+	"image/gif",
+	"image/webp",
+	"application/pdf",
+	"text/plain",
+}
+
+// SetHeaders sets the headers serving the attachment. Only the inline types
+// are displayed, everything else, like html or svg, is downloaded. The
+// sandbox policy keeps a file from running scripts even when it is opened
+// directly.
+func SetHeaders(response *ghttp.Response, file *entity.Attachment) {
+	disposition := "attachment"
+	if slices.Contains(inlineTypes, file.MimeType) {
+		disposition = "inline"
+	}
+	response.Header().Set("Content-Type", file.MimeType)
+	response.Header().Set("Content-Disposition", fmt.Sprintf("%s; filename*=UTF-8''%s", disposition, url.PathEscape(file.FileName)))
+	response.Header().Set("Content-Security-Policy", "sandbox")
+	response.Header().Set("X-Content-Type-Options", "nosniff")
+}
@@ -0,0 +1,64 @@
+package attachment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flai/internal/logic/storage"
+	"flai/internal/model/entity"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+const defaultUrlExpiry = 15 * time.Minute
+
+// UrlExpiry returns how long signed download urls stay valid.
+func UrlExpiry(ctx context.Context) time.Duration {
+	return g.Cfg().MustGet(ctx, "attachment.urlExpiry", defaultUrlExpiry).Duration()
+}
+
+// SignedURL returns a time-limited download url. Backends that can presign
+// urls serve the file directly, otherwise the url points to flai's public
+// attachment route.
+func SignedURL(ctx context.Context, attachment *entity.Attachment) (string, time.Time, error) {
+	expiry := UrlExpiry(ctx)
+	expiresAt := time.Now().Add(expiry)
+	signed, err := storage.Default(ctx).SignedURL(ctx, attachment.Path, attachment.FileName, attachment.MimeType, expiry)
+	if err == nil {
+		return signed, expiresAt, nil
+	}
+	if !errors.Is(err, storage.ErrSignNotSupported) {
+		return "", time.Time{}, err
+	}
+
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", sign(ctx, attachment.Id, expires))
+	return fmt.Sprintf("/public/attachment/%s?%s", attachment.Id, query.Encode()), expiresAt, nil
+}
+
+// VerifySignature checks a url produced by SignedURL.
+func VerifySignature(ctx context.Context, id string, expires string, signature string) bool {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return false
+	}
+	return hmac.Equal([]byte(sign(ctx, id, expires)), []byte(signature))
+}
+
+func sign(ctx context.Context, id string, expires string) string {
+	key := g.Cfg().MustGet(ctx, "attachment.signingKey").String()
+	if key == "" {
+		key = g.Cfg().MustGet(ctx, "jwt.secret").String()
+	}
+	h := hmac.New(sha256.New, []byte(key))
+	h.Write([]byte(id + ":" + expires))
+	return hex.EncodeToString(h.Sum(nil))
+}
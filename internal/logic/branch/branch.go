@@ -0,0 +1,55 @@
+package branch
+
+import (
+	"flai/internal/model/entity"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/google/uuid"
+)
+
+// AncestorChain returns the messages on the path from the root of the tree
+// to leafId, in root-first order.
+func AncestorChain(messages []*entity.Message, leafId string) ([]*entity.Message, error) {
+	byId := make(map[string]*entity.Message, len(messages))
+	for _, msg := range messages {
+		byId[msg.Id] = msg
+	}
+	leaf, ok := byId[leafId]
+	if !ok {
+		return nil, gerror.Newf("message %s not found", leafId)
+	}
+
+	var chain []*entity.Message
+	visited := make(map[string]bool)
+	for msg := leaf; msg != nil && !visited[msg.Id]; msg = byId[msg.ParentId] {
+		visited[msg.Id] = true
+		chain = append(chain, msg)
+	}
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain, nil
+}
+
+// Clone copies messages into another conversation. Every message gets a new
+// id and parent links are remapped; messages whose parent is not part of the
+// copy become roots.
+func Clone(messages []*entity.Message, conversationId string) []*entity.Message {
+	idMap := make(map[string]string, len(messages))
+	for _, msg := range messages {
+		idMap[msg.Id] = uuid.New().String()
+	}
+	cloned := make([]*entity.Message, 0, len(messages))
+	for _, msg := range messages {
+		cloned = append(cloned, &entity.Message{
+			Id:             idMap[msg.Id],
+			ConversationId: conversationId,
+			ParentId:       idMap[msg.ParentId],
+			Role:           msg.Role,
+			Content:        msg.Content,
+			MetaInfo:       msg.MetaInfo,
+			CreatedAt:      msg.CreatedAt,
+		})
+	}
+	return cloned
+}
@@ -0,0 +1,45 @@
+package export
+
+import (
+	"archive/zip"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WriteArchive writes every conversation into a zip archive. Each entry is
+// rendered in the requested format; markdown and HTML use the latest branch.
+func WriteArchive(w io.Writer, exports []*ConversationExport, format string) error {
+	zw := zip.NewWriter(w)
+	usedNames := make(map[string]bool, len(exports))
+	for _, e := range exports {
+		data, _, ext, err := Render(e, format, "")
+		if err != nil {
+			return err
+		}
+		base := FileName(e, ext)
+		name := base
+		for i := 2; usedNames[name]; i++ {
+			name = strings.TrimSuffix(base, "."+ext) + "-" + strconv.Itoa(i) + "." + ext
+		}
+		usedNames[name] = true
+
+		header := &zip.FileHeader{
+			Name:     name,
+			Method:   zip.Deflate,
+			Modified: time.Now(),
+		}
+		if e.Conversation.CreatedAt != nil {
+			header.Modified = e.Conversation.CreatedAt.Time
+		}
+		fw, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if _, err = fw.Write(data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
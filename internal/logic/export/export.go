@@ -0,0 +1,261 @@
+package export
+
+import (
+	"encoding/json"
+	"flai/internal/consts"
+	"flai/internal/logic/llm"
+	"flai/internal/model/entity"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// FormatVersion is bumped whenever the JSON export layout changes.
+const FormatVersion = 1
+
+// ConversationExport is flai's own JSON export format. It holds the full
+// message tree, linked through ParentId.
+type ConversationExport struct {
+	Version      int                 `json:"version"`
+	Conversation *ExportConversation `json:"conversation"`
+	Messages     []*ExportMessage    `json:"messages"`
+	ExportedAt   *gtime.Time         `json:"exported_at"`
+}
+
+type ExportConversation struct {
+	Id        string      `json:"id"`
+	Title     string      `json:"title"`
+	Icon      string      `json:"icon"`
+	CreatedAt *gtime.Time `json:"created_at"`
+}
+
+type ExportMessage struct {
+	Id        string              `json:"id"`
+	ParentId  string              `json:"parent_id"`
+	Role      string              `json:"role"`
+	Content   []llm.Content       `json:"content"`
+	MetaInfo  llm.MessageMetaInfo `json:"meta_info"`
+	CreatedAt *gtime.Time         `json:"created_at"`
+}
+
+// Build converts the stored conversation and its messages into the export
+// structure. Messages are expected in creation order.
+func Build(conversation *entity.Conversation, messages []*entity.Message) (*ConversationExport, error) {
+	result := &ConversationExport{
+		Version: FormatVersion,
+		Conversation: &ExportConversation{
+			Id:        conversation.Id,
+			Title:     conversation.Title,
+			Icon:      conversation.Icon,
+			CreatedAt: conversation.CreatedAt,
+		},
+		Messages:   make([]*ExportMessage, 0, len(messages)),
+		ExportedAt: gtime.Now(),
+	}
+	for _, msg := range messages {
+		var contents []llm.Content
+		if err := json.Unmarshal([]byte(msg.Content), &contents); err != nil {
+			return nil, gerror.Wrapf(err, "invalid content of message %s", msg.Id)
+		}
+		var metaInfo llm.MessageMetaInfo
+		if msg.MetaInfo != "" {
+			if err := json.Unmarshal([]byte(msg.MetaInfo), &metaInfo); err != nil {
+				return nil, gerror.Wrapf(err, "invalid meta info of message %s", msg.Id)
+			}
+		}
+		result.Messages = append(result.Messages, &ExportMessage{
+			Id:        msg.Id,
+			ParentId:  msg.ParentId,
+			Role:      msg.Role,
+			Content:   contents,
+			MetaInfo:  metaInfo,
+			CreatedAt: msg.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// ActiveBranch returns the chain of messages from the root to leafId. When
+// leafId is empty the most recently created message is used as the leaf.
+func (e *ConversationExport) ActiveBranch(leafId string) ([]*ExportMessage, error) {
+	if len(e.Messages) == 0 {
+		return nil, nil
+	}
+	byId := make(map[string]*ExportMessage, len(e.Messages))
+	for _, msg := range e.Messages {
+		byId[msg.Id] = msg
+	}
+	leaf := e.Messages[len(e.Messages)-1]
+	if leafId != "" {
+		var ok bool
+		if leaf, ok = byId[leafId]; !ok {
+			return nil, gerror.Newf("message %s not found in conversation", leafId)
+		}
+	}
+
+	var branch []*ExportMessage
+	visited := make(map[string]bool)
+	for msg := leaf; msg != nil && !visited[msg.Id]; msg = byId[msg.ParentId] {
+		visited[msg.Id] = true
+		branch = append(branch, msg)
+	}
+	for i, j := 0, len(branch)-1; i < j; i, j = i+1, j-1 {
+		branch[i], branch[j] = branch[j], branch[i]
+	}
+	return branch, nil
+}
+
+// Render renders the conversation in the given format and returns the file
+// body, its content type and file extension.
+func Render(e *ConversationExport, format string, leafId string) ([]byte, string, string, error) {
+	switch format {
+	case consts.ExportFormat.JSON:
+		data, err := json.MarshalIndent(e, "", "  ")
+		return data, "application/json; charset=utf-8", "json", err
+	case consts.ExportFormat.Markdown:
+		branch, err := e.ActiveBranch(leafId)
+		if err != nil {
+			return nil, "", "", err
+		}
+		return []byte(renderMarkdown(e, branch)), "text/markdown; charset=utf-8", "md", nil
+	case consts.ExportFormat.HTML:
+		branch, err := e.ActiveBranch(leafId)
+		if err != nil {
+			return nil, "", "", err
+		}
+		data, err := renderHTML(e, branch)
+		return data, "text/html; charset=utf-8", "html", err
+	default:
+		return nil, "", "", gerror.Newf("unsupported export format: %s", format)
+	}
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[\\/:*?"<>|\x00-\x1f]+`)
+
+// FileName returns a file system friendly name for the exported conversation.
+func FileName(e *ConversationExport, ext string) string {
+	title := strings.TrimSpace(unsafeFileNameChars.ReplaceAllString(e.Conversation.Title, "_"))
+	if title == "" {
+		title = "conversation"
+	}
+	if runes := []rune(title); len(runes) > 80 {
+		title = string(runes[:80])
+	}
+	id := e.Conversation.Id
+	if len(id) > 8 {
+		id = id[:8]
+	}
+	return fmt.Sprintf("%s-%s.%s", title, id, ext)
+}
+
+// block is a decoded content block used by the text renderers.
+type block struct {
+	Type string
+	Text string
+}
+
+func decodeBlocks(contents []llm.Content) []block {
+	var blocks []block
+	for _, content := range contents {
+		switch content.Type {
+		case consts.MessageType.Message:
+			var data llm.ContentMessage
+			if err := mapstructure.Decode(content.Data, &data); err == nil {
+				blocks = append(blocks, block{Type: content.Type, Text: data.Content})
+			}
+		case consts.MessageType.Reasoning:
+			var data llm.ContentReasoning
+			if err := mapstructure.Decode(content.Data, &data); err == nil && data.Content != "" {
+				blocks = append(blocks, block{Type: content.Type, Text: data.Content})
+			}
+		case consts.MessageType.Attachment:
+			var data llm.ContentAttachment
+			if err := mapstructure.Decode(content.Data, &data); err == nil {
+				blocks = append(blocks, block{Type: content.Type, Text: data.FileName})
+			}
+		case consts.MessageType.Image:
+			var data llm.ContentImage
+			if err := mapstructure.Decode(content.Data, &data); err == nil {
+				blocks = append(blocks, block{Type: content.Type, Text: data.FileName})
+			}
+		}
+	}
+	return blocks
+}
+
+// source is a citation shown below a message.
+type source struct {
+	Title string
+	Url   string
+}
+
+func sources(msg *ExportMessage) []source {
+	var result []source
+	for _, content := range msg.Content {
+		if content.Type != consts.MessageType.Citation {
+			continue
+		}
+		var data llm.ContentCitation
+		if err := mapstructure.Decode(content.Data, &data); err != nil {
+			continue
+		}
+		for _, citation := range data.Citations {
+			if citation.Url == "" {
+				continue
+			}
+			title := citation.Title
+			if title == "" {
+				title = citation.Url
+			}
+			result = append(result, source{Title: title, Url: citation.Url})
+		}
+	}
+	if len(result) > 0 || msg.MetaInfo.GoogleGroundingData == nil {
+		return result
+	}
+	for _, chunk := range msg.MetaInfo.GoogleGroundingData.GroundingChunks {
+		if chunk == nil || chunk.Web == nil || chunk.Web.URI == "" {
+			continue
+		}
+		title := chunk.Web.Title
+		if title == "" {
+			title = chunk.Web.URI
+		}
+		result = append(result, source{Title: title, Url: chunk.Web.URI})
+	}
+	return result
+}
+
+func roleLabel(role string) string {
+	switch role {
+	case consts.MessageRole.Assistant:
+		return "Assistant"
+	case consts.MessageRole.System:
+		return "System"
+	default:
+		return "User"
+	}
+}
+
+func modelLabel(metaInfo llm.MessageMetaInfo) string {
+	switch {
+	case metaInfo.ProviderName != "" && metaInfo.ModelName != "":
+		return metaInfo.ProviderName + " / " + metaInfo.ModelName
+	case metaInfo.ModelName != "":
+		return metaInfo.ModelName
+	default:
+		return ""
+	}
+}
+
+func usageLabel(metaInfo llm.MessageMetaInfo) string {
+	if metaInfo.PromptTokenCount == 0 && metaInfo.ResponseTokenCount == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Tokens: prompt %d · reasoning %d · response %d · cached %d",
+		metaInfo.PromptTokenCount, metaInfo.ReasoningTokenCount, metaInfo.ResponseTokenCount, metaInfo.CachedTokenCount)
+}
@@ -0,0 +1,83 @@
+package export
+
+import (
+	"bytes"
+	"flai/internal/consts"
+	"html/template"
+)
+
+type htmlMessage struct {
+	Role   string
+	Label  string
+	Model  string
+	Blocks []block
+	Source []source
+	Usage  string
+}
+
+var htmlTemplate = template.Must(template.New("conversation").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; max-width: 860px; margin: 2rem auto; padding: 0 1rem; color: #1f2328; line-height: 1.6; }
+.message { border-top: 1px solid #d0d7de; padding: 1rem 0; }
+.role { font-weight: 600; margin-bottom: .5rem; }
+.role .model { font-weight: 400; color: #656d76; margin-left: .5rem; }
+.text { white-space: pre-wrap; word-wrap: break-word; }
+details { background: #f6f8fa; border-radius: 6px; padding: .5rem .75rem; margin-bottom: .75rem; color: #656d76; }
+.sources { font-size: .9rem; }
+.usage { font-size: .8rem; color: #656d76; }
+</style>
+</head>
+<body>
+<h1>{{if .Icon}}{{.Icon}} {{end}}{{.Title}}</h1>
+{{if .ExportedAt}}<p class="usage">Exported from flai on {{.ExportedAt}}</p>{{end}}
+{{range .Messages}}
+<div class="message {{.Role}}">
+<div class="role">{{.Label}}{{if .Model}}<span class="model">{{.Model}}</span>{{end}}</div>
+{{range .Blocks}}{{if eq .Type "attachment"}}<div class="usage">Attachment: {{.Text}}</div>{{else if eq .Type "image"}}<div class="usage">Image: {{.Text}}</div>{{else if eq .Type "reasoning"}}<details><summary>Reasoning</summary><div class="text">{{.Text}}</div></details>{{else}}<div class="text">{{.Text}}</div>{{end}}
+{{end}}
+{{if .Source}}<div class="sources"><strong>Sources</strong><ol>{{range .Source}}<li><a href="{{.Url}}" rel="noopener noreferrer">{{.Title}}</a></li>{{end}}</ol></div>{{end}}
+{{if .Usage}}<div class="usage">{{.Usage}}</div>{{end}}
+</div>
+{{end}}
+</body>
+</html>
+`))
+
+func renderHTML(e *ConversationExport, branch []*ExportMessage) ([]byte, error) {
+	data := struct {
+		Title      string
+		Icon       string
+		ExportedAt string
+		Messages   []htmlMessage
+	}{
+		Title: e.Conversation.Title,
+		Icon:  e.Conversation.Icon,
+	}
+	if e.ExportedAt != nil {
+		data.ExportedAt = e.ExportedAt.Format("Y-m-d H:i:s")
+	}
+	for _, msg := range branch {
+		item := htmlMessage{
+			Role:   msg.Role,
+			Label:  roleLabel(msg.Role),
+			Blocks: decodeBlocks(msg.Content),
+			Source: sources(msg),
+			Usage:  usageLabel(msg.MetaInfo),
+		}
+		if msg.Role == consts.MessageRole.Assistant {
+			item.Model = modelLabel(msg.MetaInfo)
+		}
+		data.Messages = append(data.Messages, item)
+	}
+
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
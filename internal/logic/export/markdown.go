@@ -0,0 +1,59 @@
+package export
+
+import (
+	"flai/internal/consts"
+	"fmt"
+	"strings"
+)
+
+func renderMarkdown(e *ConversationExport, branch []*ExportMessage) string {
+	sb := strings.Builder{}
+	title := e.Conversation.Title
+	if e.Conversation.Icon != "" {
+		title = e.Conversation.Icon + " " + title
+	}
+	sb.WriteString(fmt.Sprintf("# %s\n\n", title))
+	if e.ExportedAt != nil {
+		sb.WriteString(fmt.Sprintf("_Exported from flai on %s_\n\n", e.ExportedAt.Format("Y-m-d H:i:s")))
+	}
+
+	for _, msg := range branch {
+		sb.WriteString("---\n\n")
+		heading := roleLabel(msg.Role)
+		if label := modelLabel(msg.MetaInfo); label != "" && msg.Role == consts.MessageRole.Assistant {
+			heading += " · " + label
+		}
+		sb.WriteString(fmt.Sprintf("## %s\n\n", heading))
+
+		for _, b := range decodeBlocks(msg.Content) {
+			if b.Type == consts.MessageType.Attachment {
+				sb.WriteString(fmt.Sprintf("_Attachment: %s_\n\n", b.Text))
+				continue
+			}
+			if b.Type == consts.MessageType.Image {
+				sb.WriteString(fmt.Sprintf("_Image: %s_\n\n", b.Text))
+				continue
+			}
+			if b.Type == consts.MessageType.Reasoning {
+				sb.WriteString("<details>\n<summary>Reasoning</summary>\n\n")
+				sb.WriteString(strings.TrimSpace(b.Text))
+				sb.WriteString("\n\n</details>\n\n")
+				continue
+			}
+			sb.WriteString(strings.TrimSpace(b.Text))
+			sb.WriteString("\n\n")
+		}
+
+		if list := sources(msg); len(list) > 0 {
+			sb.WriteString("**Sources**\n\n")
+			for i, s := range list {
+				sb.WriteString(fmt.Sprintf("%d. [%s](%s)\n", i+1, s.Title, s.Url))
+			}
+			sb.WriteString("\n")
+		}
+		if usage := usageLabel(msg.MetaInfo); usage != "" {
+			sb.WriteString(fmt.Sprintf("_%s_\n\n", usage))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,33 @@
+package extract
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// csvText renders rows as tab separated lines, which models read more
+// reliably than quoted csv.
+func csvText(data []byte) (string, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	if bytes.Count(data, []byte("\t")) > bytes.Count(data, []byte(",")) {
+		reader.Comma = '\t'
+	}
+	var sb strings.Builder
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// Fall back to the raw text for files that are not quite csv
+			return plainText(data)
+		}
+		sb.WriteString(strings.Join(record, "\t"))
+		sb.WriteByte('\n')
+	}
+	return sb.String(), nil
+}
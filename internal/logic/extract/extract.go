@@ -0,0 +1,116 @@
+package extract
+
+import (
+	"bytes"
+	"mime"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Supported reports whether text can be extracted from the file.
+func Supported(mimeType string, fileName string) bool {
+	return extractor(mimeType, fileName) != nil
+}
+
+// Text extracts the readable text of a document.
+func Text(mimeType string, fileName string, data []byte) (string, error) {
+	fn := extractor(mimeType, fileName)
+	if fn == nil {
+		return "", gerror.Newf("text extraction is not supported for %s", mimeType)
+	}
+	text, err := fn(data)
+	if err != nil {
+		return "", err
+	}
+	return normalize(text), nil
+}
+
+type extractFunc func(data []byte) (string, error)
+
+func extractor(mimeType string, fileName string) extractFunc {
+	if mimeType == "" || mimeType == "application/octet-stream" {
+		mimeType = strings.Split(mime.TypeByExtension(strings.ToLower(filepath.Ext(fileName))), ";")[0]
+	}
+	switch {
+	case mimeType == "application/pdf":
+		return pdfText
+	case mimeType == "application/vnd.openxmlformats-officedocument.wordprocessingml.document":
+		return docxText
+	case mimeType == "text/html" || mimeType == "application/xhtml+xml":
+		return htmlText
+	case mimeType == "text/csv" || mimeType == "text/tab-separated-values":
+		return csvText
+	case strings.HasPrefix(mimeType, "text/"),
+		mimeType == "application/json",
+		mimeType == "application/xml",
+		mimeType == "application/x-yaml",
+		mimeType == "application/yaml":
+		return plainText
+	}
+	return nil
+}
+
+func plainText(data []byte) (string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if !utf8.Valid(data) {
+		return strings.ToValidUTF8(string(data), "�"), nil
+	}
+	return string(data), nil
+}
+
+// normalize trims trailing spaces and collapses runs of blank lines.
+func normalize(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	var sb strings.Builder
+	blank := 0
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t ")
+		if line == "" {
+			blank++
+			if blank > 1 {
+				continue
+			}
+		} else {
+			blank = 0
+		}
+		sb.WriteString(line)
+		sb.WriteByte('\n')
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// Chunk splits text into pieces of at most size bytes, preferring paragraph
+// and line boundaries so chunks stay readable on their own.
+func Chunk(text string, size int) []string {
+	if size <= 0 || len(text) <= size {
+		if text == "" {
+			return nil
+		}
+		return []string{text}
+	}
+	var chunks []string
+	for len(text) > size {
+		cut := strings.LastIndex(text[:size], "\n\n")
+		if cut < size/2 {
+			cut = strings.LastIndex(text[:size], "\n")
+		}
+		if cut < size/2 {
+			cut = strings.LastIndex(text[:size], " ")
+		}
+		if cut < size/2 {
+			cut = size
+			for cut > 0 && !utf8.RuneStart(text[cut]) {
+				cut--
+			}
+		}
+		chunks = append(chunks, strings.TrimSpace(text[:cut]))
+		text = strings.TrimSpace(text[cut:])
+	}
+	if text != "" {
+		chunks = append(chunks, text)
+	}
+	return chunks
+}
@@ -0,0 +1,106 @@
+package extract
+
+import (
+	"bytes"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var htmlBlockElements = map[string]bool{
+	"p": true, "div": true, "br": true, "li": true, "tr": true, "section": true, "article": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"pre": true, "blockquote": true, "table": true, "ul": true, "ol": true, "header": true, "footer": true,
+}
+
+var htmlSkippedElements = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true, "svg": true, "head": true,
+}
+
+// htmlBoilerplateElements are left out of readable page text as they rarely
+// hold the content of a page.
+var htmlBoilerplateElements = map[string]bool{
+	"nav": true, "header": true, "footer": true, "aside": true, "form": true,
+	"button": true, "iframe": true, "menu": true, "dialog": true,
+}
+
+func htmlText(data []byte) (string, error) {
+	root, err := html.Parse(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	return nodeText(root, nil), nil
+}
+
+// Readable returns the title and main text of a web page. The text is taken
+// from the article or main element when the page has one, and navigation,
+// headers, footers and sidebars are dropped.
+func Readable(data []byte) (string, string, error) {
+	root, err := html.Parse(bytes.NewReader(data))
+	if err != nil {
+		return "", "", err
+	}
+	title := ""
+	if node := findNode(root, func(node *html.Node) bool { return node.Data == "title" }); node != nil {
+		title = strings.Join(strings.Fields(nodeText(node, nil)), " ")
+	}
+	content := findNode(root, func(node *html.Node) bool { return node.Data == "article" })
+	if content == nil {
+		content = findNode(root, func(node *html.Node) bool {
+			if node.Data == "main" {
+				return true
+			}
+			for _, attr := range node.Attr {
+				if attr.Key == "role" && attr.Val == "main" {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	if content == nil {
+		content = root
+	}
+	return title, normalize(nodeText(content, htmlBoilerplateElements)), nil
+}
+
+// nodeText collects the text below the node, separating block elements with
+// blank lines. Elements in skipped are left out in addition to the ones that
+// never hold visible text.
+func nodeText(node *html.Node, skipped map[string]bool) string {
+	var sb strings.Builder
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.ElementNode && (htmlSkippedElements[node.Data] || skipped[node.Data]) {
+			return
+		}
+		if node.Type == html.TextNode {
+			text := strings.Join(strings.Fields(node.Data), " ")
+			if text != "" {
+				sb.WriteString(text)
+				sb.WriteByte(' ')
+			}
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+		if node.Type == html.ElementNode && htmlBlockElements[node.Data] {
+			sb.WriteString("\n\n")
+		}
+	}
+	walk(node)
+	return sb.String()
+}
+
+// findNode returns the first element below the node that matches.
+func findNode(node *html.Node, match func(node *html.Node) bool) *html.Node {
+	if node.Type == html.ElementNode && match(node) {
+		return node
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if found := findNode(child, match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package extract
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/ledongthuc/pdf"
+)
+
+func pdfText(data []byte) (text string, err error) {
+	// The pdf parser panics on some malformed files
+	defer func() {
+		if r := recover(); r != nil {
+			err = gerror.Newf("failed to parse pdf: %v", r)
+		}
+	}()
+	reader, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", gerror.Wrap(err, "failed to open pdf")
+	}
+	plain, err := reader.GetPlainText()
+	if err != nil {
+		return "", gerror.Wrap(err, "failed to read pdf text")
+	}
+	content, err := io.ReadAll(plain)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
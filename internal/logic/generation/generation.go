@@ -0,0 +1,294 @@
+package generation
+
+import (
+	"context"
+	"encoding/json"
+	"flai/internal/consts"
+	"flai/internal/dao"
+	"flai/internal/logic/llm"
+	"flai/internal/model/do"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultTimeout   = 30 * time.Minute
+	defaultRetention = 10 * time.Minute
+)
+
+// Run produces the answers of a generation, streaming their events to the
+// sink.
+type Run func(ctx context.Context, sink llm.Sink) error
+
+// Job is a generation running on the server, detached from the request that
+// started it. It is the sink of its answers and keeps all their events, so
+// clients can attach at any point and replay what they missed. The state is
+// guarded by mu.
+type Job struct {
+	Id             string
+	UserId         string
+	ConversationId string
+
+	mu         sync.Mutex
+	events     [][]byte
+	size       int64
+	messageIds []string
+	done       bool
+	canceled   bool
+	status     string
+	changed    chan struct{}
+	cancel     context.CancelFunc
+}
+
+var (
+	mu   sync.Mutex
+	jobs = make(map[string]*Job)
+)
+
+// Start runs the generation answering the prompt message in the background.
+// It keeps going when the client disconnects, until it ends, is canceled or
+// times out.
+func Start(ctx context.Context, userId string, conversationId string, promptMessageId string, run Run) (*Job, error) {
+	job := &Job{
+		Id:             uuid.New().String(),
+		UserId:         userId,
+		ConversationId: conversationId,
+		status:         consts.GenerationStatus.Running,
+		changed:        make(chan struct{}),
+	}
+	_, err := dao.Generation.Ctx(ctx).Data(do.Generation{
+		Id:              job.Id,
+		UserId:          userId,
+		ConversationId:  conversationId,
+		PromptMessageId: promptMessageId,
+		Status:          job.status,
+	}).Insert()
+	if err != nil {
+		return nil, err
+	}
+
+	// The job outlives the request, only its values are kept
+	timeout := g.Cfg().MustGet(ctx, "llm.generation.timeout", defaultTimeout).Duration()
+	jobCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	job.cancel = cancel
+
+	mu.Lock()
+	jobs[job.Id] = job
+	mu.Unlock()
+
+	go job.run(jobCtx, run)
+	return job, nil
+}
+
+// Get returns the job of a generation that is running or ended recently.
+func Get(id string) *Job {
+	mu.Lock()
+	defer mu.Unlock()
+	return jobs[id]
+}
+
+// MarkInterrupted fails the generations left running by a previous process.
+func MarkInterrupted(ctx context.Context) {
+	result, err := dao.Generation.Ctx(ctx).Data(do.Generation{
+		Status:     consts.GenerationStatus.Failed,
+		Error:      "Interrupted by a server restart",
+		FinishedAt: gtime.Now(),
+		UpdatedAt:  gtime.Now(),
+	}).Where(do.Generation{Status: consts.GenerationStatus.Running}).Update()
+	if err != nil {
+		g.Log().Errorf(ctx, "Failed to mark interrupted generations: %v", err)
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected > 0 {
+		g.Log().Warningf(ctx, "Marked %d interrupted generations as failed", affected)
+	}
+}
+
+func (j *Job) run(ctx context.Context, run Run) {
+	defer j.cancel()
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = gerror.NewCodef(gcode.CodeInternalError, "generation panicked: %v", r)
+			}
+		}()
+		return run(ctx, j)
+	}()
+
+	j.mu.Lock()
+	canceled := j.canceled
+	firstMessageId := ""
+	if len(j.messageIds) > 0 {
+		firstMessageId = j.messageIds[0]
+	}
+	j.mu.Unlock()
+
+	status := consts.GenerationStatus.Completed
+	var errorMessage string
+	switch {
+	case canceled:
+		status = consts.GenerationStatus.Canceled
+	case err != nil:
+		status = consts.GenerationStatus.Failed
+		errorMessage = err.Error()
+		g.Log().Errorf(ctx, "Generation %s failed: %v", j.Id, err)
+		_ = j.Send(llm.StreamResponse{
+			MessageId: firstMessageId,
+			Type:      consts.MessageType.Error,
+			Data:      llm.ContentError{Message: errorMessage},
+		})
+	}
+	j.finish(status)
+
+	ctx = context.WithoutCancel(ctx)
+	j.save(ctx, do.Generation{
+		Status:     status,
+		Error:      errorMessage,
+		FinishedAt: gtime.Now(),
+	})
+
+	retention := g.Cfg().MustGet(ctx, "llm.generation.retention", defaultRetention).Duration()
+	time.AfterFunc(retention, func() {
+		mu.Lock()
+		delete(jobs, j.Id)
+		mu.Unlock()
+	})
+}
+
+// finish ends the event stream and sets the final status.
+func (j *Job) finish(status string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if !j.done {
+		j.append([]byte("[DONE]"))
+		j.done = true
+	}
+	j.status = status
+	j.notify()
+}
+
+func (j *Job) save(ctx context.Context, data do.Generation) {
+	data.UpdatedAt = gtime.Now()
+	_, err := dao.Generation.Ctx(ctx).Data(data).Where(do.Generation{Id: j.Id}).Update()
+	if err != nil {
+		g.Log().Errorf(ctx, "Failed to update generation %s: %v", j.Id, err)
+	}
+}
+
+// Cancel stops the generation. What was generated so far is kept.
+func (j *Job) Cancel() {
+	j.mu.Lock()
+	if j.status == consts.GenerationStatus.Running {
+		j.canceled = true
+	}
+	j.mu.Unlock()
+	j.cancel()
+}
+
+// Status returns the current status of the generation.
+func (j *Job) Status() string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.status
+}
+
+// Send records an event of an answer. The ids of new answers are stored
+// right away, so clients can find them while the generation runs.
+func (j *Job) Send(event llm.StreamResponse) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	j.mu.Lock()
+	j.append(data)
+	var messageIds string
+	if event.MessageId != "" && !slices.Contains(j.messageIds, event.MessageId) {
+		j.messageIds = append(j.messageIds, event.MessageId)
+		ids, _ := json.Marshal(j.messageIds)
+		messageIds = string(ids)
+	}
+	j.notify()
+	j.mu.Unlock()
+
+	if messageIds != "" {
+		j.save(context.Background(), do.Generation{MessageIds: messageIds})
+	}
+	return nil
+}
+
+func (j *Job) Done(messageId string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.done {
+		return
+	}
+	j.append([]byte("[DONE]"))
+	j.done = true
+	j.notify()
+}
+
+func (j *Job) Size() int64 {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.size
+}
+
+func (j *Job) append(data []byte) {
+	j.events = append(j.events, data)
+	j.size += int64(len(data))
+}
+
+// notify wakes up the clients waiting for events.
+func (j *Job) notify() {
+	close(j.changed)
+	j.changed = make(chan struct{})
+}
+
+// Attach streams the events of the job to the client of the request as
+// server-sent events, starting at the index from. It follows new events
+// until the generation has ended or the client disconnects, which leaves the
+// generation running. Every event carries its index as id, so clients can
+// resume after the last one they got.
+func Attach(ctx context.Context, job *Job, from int) error {
+	request := g.RequestFromCtx(ctx)
+	if request == nil {
+		return gerror.NewCode(gcode.CodeInvalidParameter, "Invalid request")
+	}
+	response := request.Response
+	response.Header().Set("Content-Type", "text/event-stream")
+	response.Header().Set("Cache-Control", "no-cache")
+	response.Header().Set("Connection", "keep-alive")
+	response.Header().Set("Access-Control-Allow-Origin", "*")
+	response.Header().Set("X-Generation-Id", job.Id)
+
+	from = max(0, from)
+	for {
+		job.mu.Lock()
+		events := job.events[min(from, len(job.events)):]
+		ended := job.status != consts.GenerationStatus.Running
+		changed := job.changed
+		job.mu.Unlock()
+
+		for _, event := range events {
+			response.Write(fmt.Sprintf("id: %d\ndata: %s\n\n", from, event))
+			from++
+		}
+		response.Flush()
+		if ended {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changed:
+		}
+	}
+}
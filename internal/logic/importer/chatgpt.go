@@ -0,0 +1,276 @@
+package importer
+
+import (
+	"encoding/json"
+	"flai/internal/consts"
+	"flai/internal/logic/llm"
+	"flai/internal/model/entity"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/google/uuid"
+)
+
+// chatGPTConversation mirrors one entry of ChatGPT's conversations.json.
+type chatGPTConversation struct {
+	Id          string                  `json:"id"`
+	Title       string                  `json:"title"`
+	CreateTime  float64                 `json:"create_time"`
+	CurrentNode string                  `json:"current_node"`
+	Mapping     map[string]*chatGPTNode `json:"mapping"`
+}
+
+type chatGPTNode struct {
+	Id       string          `json:"id"`
+	Message  *chatGPTMessage `json:"message"`
+	Parent   string          `json:"parent"`
+	Children []string        `json:"children"`
+}
+
+type chatGPTMessage struct {
+	Id     string `json:"id"`
+	Author struct {
+		Role string `json:"role"`
+	} `json:"author"`
+	CreateTime float64         `json:"create_time"`
+	Content    chatGPTContent  `json:"content"`
+	Recipient  string          `json:"recipient"`
+	Metadata   chatGPTMetadata `json:"metadata"`
+}
+
+type chatGPTContent struct {
+	ContentType string            `json:"content_type"`
+	Parts       []json.RawMessage `json:"parts"`
+	Text        string            `json:"text"`
+	Thoughts    []struct {
+		Summary string `json:"summary"`
+		Content string `json:"content"`
+	} `json:"thoughts"`
+}
+
+type chatGPTMetadata struct {
+	ModelSlug           string `json:"model_slug"`
+	IsVisuallyHidden    bool   `json:"is_visually_hidden_from_conversation"`
+	IsUserSystemMessage bool   `json:"is_user_system_message"`
+}
+
+func parseChatGPT(data json.RawMessage, userId string, result *Result) {
+	var source chatGPTConversation
+	if err := json.Unmarshal(data, &source); err != nil {
+		result.skip(SourceChatGPT, "", "", "invalid conversation: "+err.Error())
+		return
+	}
+	title := strings.TrimSpace(source.Title)
+	if title == "" {
+		title = "Untitled"
+	}
+
+	conversation := &Conversation{
+		Conversation: &entity.Conversation{
+			Id:        uuid.New().String(),
+			UserId:    userId,
+			Title:     title,
+			Settings:  "{}",
+			CreatedAt: fromUnix(source.CreateTime),
+		},
+	}
+
+	// Walk the tree from its roots so parents are always converted before
+	// their children. Dropped nodes are bridged to their nearest kept
+	// ancestor through idMap.
+	idMap := make(map[string]string)
+	byNewId := make(map[string]*entity.Message)
+	childCount := make(map[string]int)
+	var lastCreated *gtime.Time
+
+	var roots []string
+	for id, node := range source.Mapping {
+		if node == nil {
+			continue
+		}
+		if node.Parent == "" || source.Mapping[node.Parent] == nil {
+			roots = append(roots, id)
+		}
+	}
+	sort.Strings(roots)
+
+	visited := make(map[string]bool)
+	var walk func(nodeId string, parentId string)
+	walk = func(nodeId string, parentId string) {
+		node := source.Mapping[nodeId]
+		if node == nil || visited[nodeId] {
+			return
+		}
+		visited[nodeId] = true
+		idMap[nodeId] = parentId
+
+		if node.Message != nil {
+			if blocks, metaInfo, reason := convertChatGPTMessage(node.Message); reason != "" {
+				result.skip(SourceChatGPT, title, node.Message.Id, reason)
+			} else if len(blocks) > 0 {
+				role := node.Message.Author.Role
+				parent := byNewId[parentId]
+				if parent != nil && parent.Role == role && role == consts.MessageRole.Assistant && childCount[parentId] == 0 && len(node.Children) <= 1 {
+					// ChatGPT splits reasoning, tool use and answers into several
+					// consecutive assistant nodes; fold them into one message.
+					mergeContent(parent, blocks, metaInfo)
+				} else {
+					createdAt := fromUnix(node.Message.CreateTime)
+					if createdAt == nil || (lastCreated != nil && !createdAt.After(lastCreated)) {
+						createdAt = nextTime(lastCreated, conversation.Conversation.CreatedAt)
+					}
+					lastCreated = createdAt
+					message := newMessage(conversation.Conversation.Id, parentId, role, blocks, metaInfo, createdAt)
+					conversation.Messages = append(conversation.Messages, message)
+					byNewId[message.Id] = message
+					childCount[parentId]++
+					idMap[nodeId] = message.Id
+				}
+			}
+		}
+
+		for _, childId := range node.Children {
+			walk(childId, idMap[nodeId])
+		}
+	}
+	for _, root := range roots {
+		walk(root, "")
+	}
+
+	if len(conversation.Messages) == 0 {
+		result.skip(SourceChatGPT, title, source.Id, "conversation has no importable messages")
+		return
+	}
+	result.Conversations = append(result.Conversations, conversation)
+}
+
+// convertChatGPTMessage returns the content blocks of a ChatGPT message. A
+// non-empty reason means the message is unsupported and was skipped; empty
+// blocks without a reason mean the message is hidden and silently dropped.
+func convertChatGPTMessage(msg *chatGPTMessage) ([]llm.Content, llm.MessageMetaInfo, string) {
+	metaInfo := llm.MessageMetaInfo{
+		ProviderName: "ChatGPT",
+		ModelName:    msg.Metadata.ModelSlug,
+	}
+	role := msg.Author.Role
+	switch role {
+	case consts.MessageRole.User, consts.MessageRole.Assistant:
+	case consts.MessageRole.System:
+		return nil, metaInfo, ""
+	default:
+		return nil, metaInfo, fmt.Sprintf("unsupported author role: %s", role)
+	}
+	if msg.Metadata.IsVisuallyHidden || msg.Metadata.IsUserSystemMessage {
+		return nil, metaInfo, ""
+	}
+	if msg.Recipient != "" && msg.Recipient != "all" {
+		return nil, metaInfo, fmt.Sprintf("unsupported tool call to %s", msg.Recipient)
+	}
+
+	var blocks []llm.Content
+	switch msg.Content.ContentType {
+	case "text", "multimodal_text":
+		var texts []string
+		var skippedParts int
+		for _, part := range msg.Content.Parts {
+			var text string
+			if err := json.Unmarshal(part, &text); err != nil {
+				skippedParts++
+				continue
+			}
+			if strings.TrimSpace(text) != "" {
+				texts = append(texts, text)
+			}
+		}
+		if len(texts) > 0 {
+			blocks = append(blocks, llm.Content{
+				Type: consts.MessageType.Message,
+				Data: llm.ContentMessage{Content: strings.Join(texts, "\n\n")},
+			})
+		}
+		if skippedParts > 0 && len(blocks) == 0 {
+			return nil, metaInfo, "attachments are not supported"
+		}
+	case "code":
+		if strings.TrimSpace(msg.Content.Text) != "" {
+			blocks = append(blocks, llm.Content{
+				Type: consts.MessageType.Message,
+				Data: llm.ContentMessage{Content: "```\n" + msg.Content.Text + "\n```"},
+			})
+		}
+	case "thoughts":
+		var sb strings.Builder
+		for _, thought := range msg.Content.Thoughts {
+			if thought.Summary != "" {
+				sb.WriteString("**" + thought.Summary + "**\n\n")
+			}
+			if thought.Content != "" {
+				sb.WriteString(thought.Content + "\n\n")
+			}
+		}
+		if sb.Len() > 0 {
+			blocks = append(blocks, llm.Content{
+				Type: consts.MessageType.Reasoning,
+				Data: llm.ContentReasoning{Content: strings.TrimSpace(sb.String())},
+			})
+		}
+	case "reasoning_recap", "user_editable_context", "model_editable_context":
+		return nil, metaInfo, ""
+	default:
+		return nil, metaInfo, fmt.Sprintf("unsupported content type: %s", msg.Content.ContentType)
+	}
+	return blocks, metaInfo, ""
+}
+
+func mergeContent(message *entity.Message, blocks []llm.Content, metaInfo llm.MessageMetaInfo) {
+	var contents []llm.Content
+	_ = json.Unmarshal([]byte(message.Content), &contents)
+	contents = append(contents, blocks...)
+	contentByte, _ := json.Marshal(contents)
+	message.Content = string(contentByte)
+
+	// The model slug is often only present on the final answer node.
+	if metaInfo.ModelName != "" {
+		metaInfoByte, _ := json.Marshal(metaInfo)
+		message.MetaInfo = string(metaInfoByte)
+	}
+}
+
+func newMessage(conversationId string, parentId string, role string, blocks []llm.Content, metaInfo llm.MessageMetaInfo, createdAt *gtime.Time) *entity.Message {
+	contentByte, _ := json.Marshal(blocks)
+	metaInfoByte := []byte("{}")
+	if role == consts.MessageRole.Assistant {
+		metaInfoByte, _ = json.Marshal(metaInfo)
+	}
+	return &entity.Message{
+		Id:             uuid.New().String(),
+		ConversationId: conversationId,
+		ParentId:       parentId,
+		Role:           role,
+		Content:        string(contentByte),
+		MetaInfo:       string(metaInfoByte),
+		CreatedAt:      createdAt,
+	}
+}
+
+func fromUnix(seconds float64) *gtime.Time {
+	if seconds <= 0 {
+		return nil
+	}
+	return gtime.NewFromTime(time.UnixMicro(int64(seconds * 1e6)))
+}
+
+// nextTime keeps messages without a usable timestamp in tree order.
+func nextTime(last *gtime.Time, fallback *gtime.Time) *gtime.Time {
+	switch {
+	case last != nil:
+		return last.Add(time.Millisecond)
+	case fallback != nil:
+		return fallback.Clone()
+	default:
+		return gtime.Now()
+	}
+}
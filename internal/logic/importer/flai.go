@@ -0,0 +1,99 @@
+package importer
+
+import (
+	"encoding/json"
+	"flai/internal/consts"
+	"flai/internal/logic/export"
+	"flai/internal/logic/llm"
+	"flai/internal/model/entity"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+func parseFlai(data json.RawMessage, userId string, result *Result) {
+	var source export.ConversationExport
+	if err := json.Unmarshal(data, &source); err != nil {
+		result.skip(SourceFlai, "", "", "invalid conversation: "+err.Error())
+		return
+	}
+	if source.Conversation == nil {
+		result.skip(SourceFlai, "", "", "missing conversation")
+		return
+	}
+	if source.Version > export.FormatVersion {
+		result.skip(SourceFlai, source.Conversation.Title, source.Conversation.Id, fmt.Sprintf("unsupported export version %d", source.Version))
+		return
+	}
+	title := source.Conversation.Title
+	if title == "" {
+		title = "Untitled"
+	}
+
+	conversation := &Conversation{
+		Conversation: &entity.Conversation{
+			Id:        uuid.New().String(),
+			UserId:    userId,
+			Title:     title,
+			Icon:      source.Conversation.Icon,
+			Settings:  "{}",
+			CreatedAt: source.Conversation.CreatedAt,
+		},
+	}
+
+	// Messages get fresh ids so the same export can be imported twice.
+	idMap := make(map[string]string, len(source.Messages))
+	for _, msg := range source.Messages {
+		idMap[msg.Id] = uuid.New().String()
+	}
+	for _, msg := range source.Messages {
+		parentId, ok := idMap[msg.ParentId]
+		if msg.ParentId != "" && !ok {
+			delete(idMap, msg.Id)
+			result.skip(SourceFlai, title, msg.Id, "parent message not found")
+			continue
+		}
+		// Children of a skipped message are attached to its parent instead.
+		var reason string
+		// Files are not part of the archive, attachment and image blocks
+		// would refer to attachments of the exporting instance or user
+		content := slices.DeleteFunc(slices.Clone(msg.Content), func(content llm.Content) bool {
+			return content.Type == consts.MessageType.Attachment || content.Type == consts.MessageType.Image
+		})
+		if len(content) == 0 && len(msg.Content) > 0 {
+			reason = "message only contains files"
+		}
+		contentByte, err := json.Marshal(content)
+		if err != nil {
+			reason = "invalid content"
+		}
+		metaInfoByte, err := json.Marshal(msg.MetaInfo)
+		if err != nil {
+			reason = "invalid meta info"
+		}
+		if msg.Role != consts.MessageRole.User && msg.Role != consts.MessageRole.Assistant && msg.Role != consts.MessageRole.System {
+			reason = fmt.Sprintf("unsupported role: %s", msg.Role)
+		}
+		if reason != "" {
+			idMap[msg.Id] = parentId
+			result.skip(SourceFlai, title, msg.Id, reason)
+			continue
+		}
+		conversation.Messages = append(conversation.Messages, &entity.Message{
+			Id:             idMap[msg.Id],
+			ConversationId: conversation.Conversation.Id,
+			ParentId:       parentId,
+			Role:           msg.Role,
+			Content:        string(contentByte),
+			MetaInfo:       string(metaInfoByte),
+			CreatedAt:      msg.CreatedAt,
+		})
+	}
+
+	if len(conversation.Messages) == 0 {
+		result.skip(SourceFlai, title, source.Conversation.Id, "conversation has no importable messages")
+		return
+	}
+	result.Conversations = append(result.Conversations, conversation)
+}
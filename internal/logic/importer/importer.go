@@ -0,0 +1,138 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"flai/internal/model/entity"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Supported import sources
+const (
+	SourceChatGPT = "chatgpt"
+	SourceFlai    = "flai"
+)
+
+// maxArchiveEntrySize caps a single json file inside an uploaded zip archive.
+const maxArchiveEntrySize = 256 << 20
+
+// Conversation is a converted conversation ready to be inserted.
+type Conversation struct {
+	Conversation *entity.Conversation
+	Messages     []*entity.Message
+}
+
+// Skipped describes an item that could not be imported.
+type Skipped struct {
+	Source       string `json:"source"`
+	Conversation string `json:"conversation"`
+	ItemId       string `json:"item_id"`
+	Reason       string `json:"reason"`
+}
+
+// Result is the outcome of parsing an uploaded file.
+type Result struct {
+	Conversations []*Conversation
+	Skipped       []*Skipped
+}
+
+func (r *Result) skip(source string, conversation string, itemId string, reason string) {
+	r.Skipped = append(r.Skipped, &Skipped{
+		Source:       source,
+		Conversation: conversation,
+		ItemId:       itemId,
+		Reason:       reason,
+	})
+}
+
+// Parse converts an uploaded ChatGPT or flai export into conversations owned
+// by userId. Zip archives are searched for json files.
+func Parse(fileName string, data []byte, userId string) (*Result, error) {
+	result := &Result{}
+	if strings.EqualFold(path.Ext(fileName), ".zip") || bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		if err := parseArchive(data, userId, result); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	if err := parseJSON(fileName, data, userId, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func parseArchive(data []byte, userId string, result *Result) error {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return gerror.Wrap(err, "invalid zip archive")
+	}
+	found := false
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() || !strings.EqualFold(path.Ext(file.Name), ".json") {
+			continue
+		}
+		if file.UncompressedSize64 > maxArchiveEntrySize {
+			result.skip("", "", file.Name, "file too large")
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return gerror.Wrapf(err, "failed to open %s", file.Name)
+		}
+		content, err := io.ReadAll(io.LimitReader(rc, maxArchiveEntrySize))
+		_ = rc.Close()
+		if err != nil {
+			return gerror.Wrapf(err, "failed to read %s", file.Name)
+		}
+		if err = parseJSON(file.Name, content, userId, result); err != nil {
+			result.skip("", "", file.Name, err.Error())
+			continue
+		}
+		found = true
+	}
+	if !found {
+		return gerror.New("no importable json file found in archive")
+	}
+	return nil
+}
+
+// parseJSON detects the export flavour by its shape. Both formats may hold a
+// single conversation object or an array of them.
+func parseJSON(fileName string, data []byte, userId string, result *Result) error {
+	data = bytes.TrimSpace(data)
+	var items []json.RawMessage
+	if bytes.HasPrefix(data, []byte("[")) {
+		if err := json.Unmarshal(data, &items); err != nil {
+			return gerror.Wrapf(err, "invalid json in %s", fileName)
+		}
+	} else {
+		items = []json.RawMessage{data}
+	}
+
+	for i, item := range items {
+		var probe map[string]json.RawMessage
+		if err := json.Unmarshal(item, &probe); err != nil {
+			result.skip("", "", itemName(fileName, i), "not a json object")
+			continue
+		}
+		switch {
+		case probe["mapping"] != nil:
+			parseChatGPT(item, userId, result)
+		case probe["conversation"] != nil && probe["messages"] != nil:
+			parseFlai(item, userId, result)
+		default:
+			result.skip("", "", itemName(fileName, i), "unrecognized export format")
+		}
+	}
+	return nil
+}
+
+func itemName(fileName string, index int) string {
+	return fmt.Sprintf("%s#%d", fileName, index)
+}
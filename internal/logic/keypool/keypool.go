@@ -0,0 +1,254 @@
+package keypool
+
+import (
+	"context"
+	"flai/internal/dao"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// Key selection strategies of a provider
+const (
+	RoundRobin       = "round_robin"
+	LeastRateLimited = "least_rate_limited"
+)
+
+const (
+	defaultRateLimitCooldown    = time.Minute
+	defaultUnauthorizedCooldown = time.Hour
+	maxErrorLength              = 500
+)
+
+// Key is an API key of a provider pool. The cooldown is only kept in memory,
+// the counters are written to the provider_key table. The state is guarded
+// by mu.
+type Key struct {
+	Id     string
+	ApiKey string
+
+	lastUsedAt        time.Time
+	lastRateLimitedAt time.Time
+	cooldownUntil     time.Time
+}
+
+type pool struct {
+	keys []*Key
+	next int
+}
+
+var (
+	mu    sync.Mutex
+	pools = make(map[string]*pool)
+)
+
+// Load builds the pools from the active keys of all providers.
+func Load(ctx context.Context) {
+	var keys []*entity.ProviderKey
+	err := dao.ProviderKey.Ctx(ctx).Where(do.ProviderKey{IsActive: 1}).OrderAsc("created_at").Scan(&keys)
+	if err != nil {
+		g.Log().Errorf(ctx, "Failed to load provider keys: %v", err)
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	pools = make(map[string]*pool)
+	for _, key := range keys {
+		add(key)
+	}
+	g.Log().Infof(ctx, "Loaded %d provider keys", len(keys))
+}
+
+// Add puts a key into the pool of its provider.
+func Add(key *entity.ProviderKey) {
+	mu.Lock()
+	defer mu.Unlock()
+	add(key)
+}
+
+func add(key *entity.ProviderKey) {
+	p := pools[key.ProviderId]
+	if p == nil {
+		p = &pool{}
+		pools[key.ProviderId] = p
+	}
+	k := &Key{Id: key.Id, ApiKey: key.ApiKey}
+	if key.LastUsedAt != nil {
+		k.lastUsedAt = key.LastUsedAt.Time
+	}
+	if key.LastRateLimitedAt != nil {
+		k.lastRateLimitedAt = key.LastRateLimitedAt.Time
+	}
+	p.keys = append(p.keys, k)
+}
+
+// Remove takes a key out of the pool of its provider.
+func Remove(providerId string, keyId string) {
+	mu.Lock()
+	defer mu.Unlock()
+	p := pools[providerId]
+	if p == nil {
+		return
+	}
+	for i, key := range p.keys {
+		if key.Id == keyId {
+			p.keys = append(p.keys[:i], p.keys[i+1:]...)
+			break
+		}
+	}
+	if len(p.keys) == 0 {
+		delete(pools, providerId)
+	}
+}
+
+// Size returns the number of keys in the pool of the provider.
+func Size(providerId string) int {
+	mu.Lock()
+	defer mu.Unlock()
+	if p := pools[providerId]; p != nil {
+		return len(p.keys)
+	}
+	return 0
+}
+
+// Acquire picks a key of the provider with the strategy. Keys cooling down
+// are skipped, when all of them are the one available first is used. It
+// returns nil when the provider has no pool.
+func Acquire(providerId string, strategy string) *Key {
+	mu.Lock()
+	defer mu.Unlock()
+	p := pools[providerId]
+	if p == nil || len(p.keys) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	var picked *Key
+	switch strategy {
+	case LeastRateLimited:
+		for _, key := range p.keys {
+			if key.cooldownUntil.Before(now) && (picked == nil || key.rateLimitedBefore(picked)) {
+				picked = key
+			}
+		}
+	default:
+		for i := range p.keys {
+			key := p.keys[(p.next+i)%len(p.keys)]
+			if key.cooldownUntil.Before(now) {
+				picked = key
+				p.next = (p.next + i + 1) % len(p.keys)
+				break
+			}
+		}
+	}
+	if picked == nil {
+		for _, key := range p.keys {
+			if picked == nil || key.cooldownUntil.Before(picked.cooldownUntil) {
+				picked = key
+			}
+		}
+	}
+
+	picked.lastUsedAt = now
+	return picked
+}
+
+// rateLimitedBefore orders keys by when they were last rate limited, then by
+// when they were last used.
+func (k *Key) rateLimitedBefore(other *Key) bool {
+	if !k.lastRateLimitedAt.Equal(other.lastRateLimitedAt) {
+		return k.lastRateLimitedAt.Before(other.lastRateLimitedAt)
+	}
+	return k.lastUsedAt.Before(other.lastUsedAt)
+}
+
+// Report records the outcome of a request made with the key. Status is the
+// HTTP status of a failed request, or 0. Keys answering 429 or 401 are put
+// into cooldown.
+func Report(ctx context.Context, key *Key, status int, err error) {
+	if key == nil {
+		return
+	}
+	now := time.Now()
+	data := g.Map{
+		dao.ProviderKey.Columns().RequestCount: &gdb.Counter{Field: dao.ProviderKey.Columns().RequestCount, Value: 1},
+		dao.ProviderKey.Columns().LastUsedAt:   gtime.New(now),
+	}
+
+	if err != nil {
+		lastError := []rune(err.Error())
+		if len(lastError) > maxErrorLength {
+			lastError = lastError[:maxErrorLength]
+		}
+		data[dao.ProviderKey.Columns().ErrorCount] = &gdb.Counter{Field: dao.ProviderKey.Columns().ErrorCount, Value: 1}
+		data[dao.ProviderKey.Columns().LastError] = string(lastError)
+		data[dao.ProviderKey.Columns().LastErrorAt] = gtime.New(now)
+	}
+
+	switch status {
+	case http.StatusTooManyRequests:
+		cooldown := g.Cfg().MustGet(ctx, "llm.keyCooldown.rateLimited", defaultRateLimitCooldown).Duration()
+		mu.Lock()
+		key.lastRateLimitedAt = now
+		key.cooldownUntil = now.Add(cooldown)
+		mu.Unlock()
+		data[dao.ProviderKey.Columns().RateLimitedCount] = &gdb.Counter{Field: dao.ProviderKey.Columns().RateLimitedCount, Value: 1}
+		data[dao.ProviderKey.Columns().LastRateLimitedAt] = gtime.New(now)
+	case http.StatusUnauthorized:
+		cooldown := g.Cfg().MustGet(ctx, "llm.keyCooldown.unauthorized", defaultUnauthorizedCooldown).Duration()
+		mu.Lock()
+		key.cooldownUntil = now.Add(cooldown)
+		mu.Unlock()
+	}
+
+	_, err = dao.ProviderKey.Ctx(context.WithoutCancel(ctx)).Where(do.ProviderKey{Id: key.Id}).Data(data).Update()
+	if err != nil {
+		g.Log().Warningf(ctx, "Failed to update usage of provider key %s: %v", key.Id, err)
+	}
+}
+
+// Health is the runtime state of a key.
+type Health struct {
+	InPool        bool        `json:"in_pool"`
+	CoolingDown   bool        `json:"cooling_down"`
+	CooldownUntil *gtime.Time `json:"cooldown_until,omitempty"`
+}
+
+// GetHealth returns the state of the key in the pool of the provider.
+func GetHealth(providerId string, keyId string) Health {
+	mu.Lock()
+	defer mu.Unlock()
+	health := Health{}
+	p := pools[providerId]
+	if p == nil {
+		return health
+	}
+	for _, key := range p.keys {
+		if key.Id != keyId {
+			continue
+		}
+		health.InPool = true
+		if key.cooldownUntil.After(time.Now()) {
+			health.CoolingDown = true
+			health.CooldownUntil = gtime.New(key.cooldownUntil)
+		}
+	}
+	return health
+}
+
+// Mask hides all but the last characters of an API key.
+func Mask(apiKey string) string {
+	const visible = 4
+	if len(apiKey) <= visible*2 {
+		return strings.Repeat("*", len(apiKey))
+	}
+	return strings.Repeat("*", 8) + apiKey[len(apiKey)-visible:]
+}
@@ -0,0 +1,224 @@
+package knowledge
+
+import (
+	"context"
+	"flai/internal/consts"
+	"flai/internal/dao"
+	"flai/internal/logic"
+	"flai/internal/logic/attachment"
+	"flai/internal/logic/extract"
+	"flai/internal/logic/llm"
+	"flai/internal/model"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+	"io"
+	"path/filepath"
+	"slices"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultMaxSize   = 50 << 20
+	defaultChunkSize = 1500
+	defaultTopK      = 5
+)
+
+// MaxSize returns the largest accepted document in bytes.
+func MaxSize(ctx context.Context) int64 {
+	return g.Cfg().MustGet(ctx, "knowledge.maxSize", defaultMaxSize).Int64()
+}
+
+// TopK returns how many chunks are retrieved for a prompt.
+func TopK(ctx context.Context) int {
+	return g.Cfg().MustGet(ctx, "knowledge.topK", defaultTopK).Int()
+}
+
+func chunkSize(ctx context.Context) int {
+	return g.Cfg().MustGet(ctx, "knowledge.chunkSize", defaultChunkSize).Int()
+}
+
+// Provider returns the provider used to embed the documents of a knowledge
+// base.
+func Provider(knowledgeBase *entity.KnowledgeBase) (*logic.SimpleProviderInfo, error) {
+	providerInfo := logic.ProviderMap[knowledgeBase.ProviderId]
+	if providerInfo == nil {
+		return nil, gerror.Newf("provider of knowledge base %s is not available", knowledgeBase.Name)
+	}
+	return providerInfo, nil
+}
+
+// CanManage reports whether the user may change a knowledge base. Personal
+// knowledge bases are managed by their owner, team knowledge bases by their
+// creator while still a member and by the team owner.
+func CanManage(ctx context.Context, knowledgeBase *entity.KnowledgeBase, userId string) (bool, error) {
+	if knowledgeBase.TeamId == "" {
+		return knowledgeBase.UserId == userId, nil
+	}
+	role, err := dao.GetTeamRole(ctx, knowledgeBase.TeamId, userId)
+	if err != nil {
+		return false, err
+	}
+	if role == "" {
+		return false, nil
+	}
+	return knowledgeBase.UserId == userId || role == consts.TeamRole.Owner, nil
+}
+
+// Ingest extracts the text of a document, splits it into chunks and stores
+// the chunks with their embeddings.
+func Ingest(ctx context.Context, knowledgeBase *entity.KnowledgeBase, userId string, fileName string, reader io.Reader) (*entity.KnowledgeDocument, error) {
+	maxSize := MaxSize(ctx)
+	data, err := io.ReadAll(io.LimitReader(reader, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, gerror.Newf("file exceeds the maximum size of %d bytes", maxSize)
+	}
+	mimeType := attachment.DetectMimeType(fileName, data)
+	if !extract.Supported(mimeType, fileName) {
+		return nil, gerror.Newf("%s files are not supported", mimeType)
+	}
+	text, err := extract.Text(mimeType, fileName, data)
+	if err != nil {
+		return nil, err
+	}
+	chunks := extract.Chunk(text, chunkSize(ctx))
+	if len(chunks) == 0 {
+		return nil, gerror.New("no text could be extracted from the file")
+	}
+
+	providerInfo, err := Provider(knowledgeBase)
+	if err != nil {
+		return nil, err
+	}
+	embeddings, err := llm.Embed(ctx, providerInfo, knowledgeBase.EmbeddingModel, chunks)
+	if err != nil {
+		return nil, err
+	}
+
+	document := &entity.KnowledgeDocument{
+		Id:              uuid.New().String(),
+		KnowledgeBaseId: knowledgeBase.Id,
+		UserId:          userId,
+		FileName:        filepath.Base(fileName),
+		MimeType:        mimeType,
+		Size:            int64(len(data)),
+		ChunkCount:      len(chunks),
+	}
+	chunkList := make([]*entity.KnowledgeChunk, 0, len(chunks))
+	for i, chunk := range chunks {
+		chunkList = append(chunkList, &entity.KnowledgeChunk{
+			Id:              uuid.New().String(),
+			KnowledgeBaseId: knowledgeBase.Id,
+			DocumentId:      document.Id,
+			ChunkIndex:      i,
+			Content:         chunk,
+			Embedding:       dao.VectorLiteral(embeddings[i]),
+		})
+	}
+	err = dao.KnowledgeDocument.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		if _, err := dao.KnowledgeDocument.Ctx(ctx).Data(document).OmitEmpty().Insert(); err != nil {
+			return err
+		}
+		_, err := dao.KnowledgeChunk.Ctx(ctx).Data(chunkList).OmitEmpty().Batch(100).Insert()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// DeleteDocuments removes documents and their chunks.
+func DeleteDocuments(ctx context.Context, documentIds ...string) error {
+	if len(documentIds) == 0 {
+		return nil
+	}
+	return dao.KnowledgeDocument.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		if _, err := dao.KnowledgeChunk.Ctx(ctx).Where(do.KnowledgeChunk{DocumentId: documentIds}).Delete(); err != nil {
+			return err
+		}
+		_, err := dao.KnowledgeDocument.Ctx(ctx).Where(do.KnowledgeDocument{Id: documentIds}).Delete()
+		return err
+	})
+}
+
+// Delete removes a knowledge base with all of its documents.
+func Delete(ctx context.Context, knowledgeBase *entity.KnowledgeBase) error {
+	return dao.KnowledgeBase.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		if _, err := dao.KnowledgeChunk.Ctx(ctx).Where(do.KnowledgeChunk{KnowledgeBaseId: knowledgeBase.Id}).Delete(); err != nil {
+			return err
+		}
+		if _, err := dao.KnowledgeDocument.Ctx(ctx).Where(do.KnowledgeDocument{KnowledgeBaseId: knowledgeBase.Id}).Delete(); err != nil {
+			return err
+		}
+		_, err := dao.KnowledgeBase.Ctx(ctx).Where(do.KnowledgeBase{Id: knowledgeBase.Id}).Delete()
+		return err
+	})
+}
+
+// ErrNotFound is returned by Retrieve when a knowledge base does not exist or
+// is not accessible to the user.
+var ErrNotFound = gerror.New("knowledge base not found")
+
+// Retrieve returns the chunks of the knowledge bases most similar to the
+// query. The query is embedded once per embedding model in use.
+func Retrieve(ctx context.Context, userId string, knowledgeBaseIds []string, query string) ([]*model.KnowledgeHit, error) {
+	if len(knowledgeBaseIds) == 0 || query == "" {
+		return nil, nil
+	}
+	knowledgeBases, err := dao.FetchAccessibleKnowledgeBases(ctx, userId, knowledgeBaseIds...)
+	if err != nil {
+		return nil, err
+	}
+	if len(knowledgeBases) != len(slices.Compact(slices.Sorted(slices.Values(knowledgeBaseIds)))) {
+		return nil, ErrNotFound
+	}
+
+	type embeddingModel struct {
+		providerId string
+		model      string
+	}
+	groups := make(map[embeddingModel][]string)
+	for _, knowledgeBase := range knowledgeBases {
+		key := embeddingModel{providerId: knowledgeBase.ProviderId, model: knowledgeBase.EmbeddingModel}
+		groups[key] = append(groups[key], knowledgeBase.Id)
+	}
+
+	topK := TopK(ctx)
+	var hits []*model.KnowledgeHit
+	for key, ids := range groups {
+		providerInfo := logic.ProviderMap[key.providerId]
+		if providerInfo == nil {
+			return nil, gerror.Newf("embedding provider %s is not available", key.providerId)
+		}
+		embeddings, err := llm.Embed(ctx, providerInfo, key.model, []string{query})
+		if err != nil {
+			return nil, err
+		}
+		groupHits, err := dao.SearchKnowledgeChunks(ctx, ids, embeddings[0], topK)
+		if err != nil {
+			return nil, err
+		}
+		hits = append(hits, groupHits...)
+	}
+
+	slices.SortFunc(hits, func(a, b *model.KnowledgeHit) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		}
+		return 0
+	})
+	if len(hits) > topK {
+		hits = hits[:topK]
+	}
+	return hits, nil
+}
@@ -0,0 +1,44 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+func init() {
+	RegisterTool(&Tool{
+		Name:        "current_time",
+		Description: "Get the current date and time, optionally in an IANA time zone such as Europe/Berlin.",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"time_zone": map[string]any{
+					"type":        "string",
+					"description": "IANA time zone name, defaults to UTC",
+				},
+			},
+		},
+		Execute: currentTime,
+	})
+}
+
+func currentTime(ctx context.Context, arguments string) (*ToolOutput, error) {
+	var args struct {
+		TimeZone string `json:"time_zone"`
+	}
+	if arguments != "" {
+		if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+			return nil, err
+		}
+	}
+	location := time.UTC
+	if args.TimeZone != "" {
+		loc, err := time.LoadLocation(args.TimeZone)
+		if err != nil {
+			return nil, err
+		}
+		location = loc
+	}
+	return &ToolOutput{Content: time.Now().In(location).Format("Monday " + time.RFC3339)}, nil
+}
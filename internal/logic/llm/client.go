@@ -4,32 +4,148 @@ import (
 	"context"
 	"encoding/json"
 	"flai/internal/consts"
+	"flai/internal/dao"
 	"flai/internal/logic"
+	"flai/internal/logic/attachment"
+	"flai/internal/logic/extract"
+	"flai/internal/logic/keypool"
+	"flai/internal/model"
+	"flai/internal/model/do"
 	"flai/internal/model/entity"
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
 	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/google/uuid"
 )
 
 type Client interface {
-	StreamChat(ctx context.Context, response *ghttp.Response, providerInfo *logic.SimpleProviderInfo, modelConfig *logic.ModelConfig, historyMessages []*entity.Message, newMessage *entity.Message, tools []string) error
+	StreamChat(ctx context.Context, sink Sink, providerInfo *logic.SimpleProviderInfo, modelConfig *logic.ModelConfig, historyMessages []*entity.Message, newMessage *entity.Message, options *ChatOptions) error
 	GenerateTitle(ctx context.Context, providerInfo *logic.SimpleProviderInfo, modelConfig *logic.ModelConfig, systemInstruction string, content string) (*TitleGenerationResponse, error)
+	Embed(ctx context.Context, providerInfo *logic.SimpleProviderInfo, model string, inputs []string) ([][]float32, error)
 }
 
-func StreamChat(ctx context.Context, response *ghttp.Response, providerInfo *logic.SimpleProviderInfo, modelConfig *logic.ModelConfig, historyMessages []*entity.Message, newMessage *entity.Message, tools []string) error {
-	var client Client
+// ChatOptions carries the optional inputs of a chat request.
+type ChatOptions struct {
+	// UserId owns the files created by tools.
+	UserId string
+	Tools  []string
+	// Knowledge holds the knowledge base chunks retrieved for the prompt.
+	// They are sent to the model as context and returned as citations.
+	Knowledge []*model.KnowledgeHit
+	// Settings are the validated generation settings of the conversation.
+	Settings *model.ChatSettings
+	// Alias is the model alias the request was routed from.
+	Alias string
+
+	// messageId and fallbackFrom are set by StreamChat, so every attempt
+	// answers with the same message.
+	messageId    string
+	fallbackFrom string
+}
+
+func newClient(providerInfo *logic.SimpleProviderInfo) (Client, error) {
 	switch providerInfo.ProviderType {
 	case consts.ProviderType.OpenAI:
-		client = &OpenAIClient{}
+		return &OpenAIClient{}, nil
 	case consts.ProviderType.Gemini:
-		client = &GeminiClient{}
+		return &GeminiClient{}, nil
 	default:
-		return gerror.Newf("unsupported provider type: %s", providerInfo.ProviderType)
+		return nil, gerror.Newf("unsupported provider type: %s", providerInfo.ProviderType)
 	}
-	return client.StreamChat(ctx, response, providerInfo, modelConfig, historyMessages, newMessage, tools)
+}
+
+// StreamChat streams the answer of the model to the prompt. Transient errors
+// raised before anything was streamed are retried with backoff, and then
+// handed to the next model of the fallback chain configured for the model.
+func StreamChat(ctx context.Context, sink Sink, providerInfo *logic.SimpleProviderInfo, modelConfig *logic.ModelConfig, historyMessages []*entity.Message, newMessage *entity.Message, options *ChatOptions) error {
+	if options == nil {
+		options = &ChatOptions{}
+	}
+	if options.messageId == "" {
+		options.messageId = uuid.New().String()
+	}
+
+	// Knowledge citations are streamed once, ahead of all attempts
+	if citations := knowledgeCitations(options.Knowledge); len(citations) > 0 {
+		err := sink.Send(StreamResponse{
+			MessageId: options.messageId,
+			Type:      consts.MessageType.Citation,
+			Data:      ContentCitation{Citations: citations},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	retry := GetRetryConfig(ctx)
+	var err error
+	for i, candidate := range chatCandidates(providerInfo, modelConfig, options.Settings) {
+		client, clientErr := newClient(candidate.providerInfo)
+		if clientErr != nil {
+			err = clientErr
+			continue
+		}
+		if i > 0 {
+			g.Log().Warningf(ctx, "Falling back from model %s to %s: %v", modelConfig.Name, candidate.modelConfig.Name, err)
+			options.fallbackFrom = modelConfig.Name
+		}
+		for attempt := 1; ; attempt++ {
+			written := sink.Size()
+			keyed, key := withKey(candidate.providerInfo)
+			err = client.StreamChat(ctx, sink, keyed, candidate.modelConfig, historyMessages, newMessage, options)
+			reportKey(ctx, key, err)
+			if err == nil || sink.Size() != written {
+				return err
+			}
+			// A rejected key is worth retrying when the pool has others
+			rejectedKey := statusCode(err) == http.StatusUnauthorized && keypool.Size(candidate.providerInfo.Id) > 1
+			if !isTransient(err) && !rejectedKey {
+				return err
+			}
+			if attempt >= retry.MaxAttempts {
+				break
+			}
+			g.Log().Warningf(ctx, "Retrying model %s after attempt %d: %v", candidate.modelConfig.Name, attempt, err)
+			if !sleep(ctx, retry.delay(attempt)) {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// embedBatchSize stays below the per-request input limits of the providers.
+const embedBatchSize = 64
+
+// Embed returns one embedding per input using the embedding model of the
+// provider.
+func Embed(ctx context.Context, providerInfo *logic.SimpleProviderInfo, model string, inputs []string) ([][]float32, error) {
+	client, err := newClient(providerInfo)
+	if err != nil {
+		return nil, err
+	}
+	embeddings := make([][]float32, 0, len(inputs))
+	for start := 0; start < len(inputs); start += embedBatchSize {
+		batch := inputs[start:min(start+embedBatchSize, len(inputs))]
+		keyed, key := withKey(providerInfo)
+		vectors, err := client.Embed(ctx, keyed, model, batch)
+		reportKey(ctx, key, err)
+		if err != nil {
+			return nil, err
+		}
+		if len(vectors) != len(batch) {
+			return nil, gerror.Newf("expected %d embeddings, got %d", len(batch), len(vectors))
+		}
+		embeddings = append(embeddings, vectors...)
+	}
+	return embeddings, nil
 }
 
 func GenerateTitle(ctx context.Context, messages []*entity.Message) (*TitleGenerationResponse, error) {
@@ -64,17 +180,15 @@ func GenerateTitle(ctx context.Context, messages []*entity.Message) (*TitleGener
 	//sb.WriteString("</output_format>")
 	xmlContent := sb.String()
 
-	var client Client
-	switch providerInfo.ProviderType {
-	case consts.ProviderType.OpenAI:
-		client = &OpenAIClient{}
-	case consts.ProviderType.Gemini:
-		client = &GeminiClient{}
-	default:
-		return nil, gerror.Newf("unsupported provider type: %s", providerInfo.ProviderType)
+	client, err := newClient(providerInfo)
+	if err != nil {
+		return nil, err
 	}
 
-	return client.GenerateTitle(ctx, providerInfo, modelConfig, template, xmlContent)
+	keyed, key := withKey(providerInfo)
+	title, err := client.GenerateTitle(ctx, keyed, modelConfig, template, xmlContent)
+	reportKey(ctx, key, err)
+	return title, err
 }
 
 func StreamToClient(response *ghttp.Response, content any) error {
@@ -102,3 +216,194 @@ func appendContent(contentBuilder *strings.Builder, messageType string, contentL
 		*contentList = append(*contentList, content)
 	}
 }
+
+// defaultCheckpointInterval is how often an answer is saved while it is
+// generated.
+const defaultCheckpointInterval = 2 * time.Second
+
+// answerStore saves an answer while it is generated, so what was streamed
+// survives a failure of the generation. The first save inserts the message,
+// later ones update its content.
+type answerStore struct {
+	message  entity.Message
+	inserted bool
+	interval time.Duration
+	savedAt  time.Time
+}
+
+func newAnswerStore(ctx context.Context, message entity.Message) *answerStore {
+	return &answerStore{
+		message:  message,
+		interval: g.Cfg().MustGet(ctx, "llm.generation.checkpointInterval", defaultCheckpointInterval).Duration(),
+		savedAt:  time.Now(),
+	}
+}
+
+// due reports whether the answer should be checkpointed.
+func (s *answerStore) due() bool {
+	return time.Since(s.savedAt) >= s.interval
+}
+
+func (s *answerStore) save(ctx context.Context, contents []Content, metaInfo MessageMetaInfo) {
+	s.savedAt = time.Now()
+	contentListByte, err := json.Marshal(contents)
+	if err != nil {
+		g.Log().Errorf(ctx, "Failed to marshal content list: %v", err)
+		return
+	}
+	messageMetaInfoByte, err := json.Marshal(metaInfo)
+	if err != nil {
+		g.Log().Errorf(ctx, "Failed to marshal meta info: %v", err)
+		return
+	}
+	s.message.Content = string(contentListByte)
+	s.message.MetaInfo = string(messageMetaInfoByte)
+	if s.inserted {
+		_, err = dao.Message.Ctx(ctx).Data(do.Message{
+			Content:  s.message.Content,
+			MetaInfo: s.message.MetaInfo,
+		}).Where(do.Message{Id: s.message.Id}).Update()
+	} else {
+		_, err = dao.Message.Ctx(ctx).Data(s.message).Insert()
+	}
+	if err != nil {
+		g.Log().Errorf(ctx, "Failed to save message: %v", err)
+		return
+	}
+	s.inserted = true
+}
+
+// replayMetaInfo returns the meta info of an assistant message generated by
+// the model of the current request, or nil. Reasoning state is only valid for
+// the model that produced it. Names can be reused after a provider or model
+// is renamed, so the ids are compared; messages without ids are not replayed.
+func replayMetaInfo(msg *entity.Message, providerInfo *logic.SimpleProviderInfo, modelConfig *logic.ModelConfig) *MessageMetaInfo {
+	if msg.Role != consts.MessageRole.Assistant || msg.MetaInfo == "" {
+		return nil
+	}
+	var metaInfo MessageMetaInfo
+	if err := json.Unmarshal([]byte(msg.MetaInfo), &metaInfo); err != nil {
+		return nil
+	}
+	if metaInfo.ProviderId == "" || metaInfo.ProviderId != providerInfo.Id || metaInfo.ModelId != modelConfig.ID {
+		return nil
+	}
+	return &metaInfo
+}
+
+// decodeContents parses the stored content blocks of a message.
+func decodeContents(msg *entity.Message) ([]Content, error) {
+	var contents []Content
+	if err := json.Unmarshal([]byte(msg.Content), &contents); err != nil {
+		return nil, err
+	}
+	return contents, nil
+}
+
+const (
+	documentChunkSize = 8000
+	// documentCharsPerToken estimates how much extracted text fits into the
+	// model context; half of the context window is left for the chat itself.
+	documentCharsPerToken = 2
+	documentMaxChars      = 400000
+)
+
+// documentContext extracts the text of an attachment the model cannot read
+// natively and returns it as labelled chunks to insert into the prompt.
+func documentContext(ctx context.Context, modelConfig *logic.ModelConfig, file *entity.Attachment, data []byte) ([]string, error) {
+	text, err := attachment.ExtractText(ctx, file, data)
+	if err != nil {
+		return nil, err
+	}
+	maxChars := documentMaxChars
+	if modelConfig.Limit.Context > 0 {
+		maxChars = min(maxChars, int(modelConfig.Limit.Context)*documentCharsPerToken)
+	}
+	truncated := false
+	if len(text) > maxChars {
+		text = strings.ToValidUTF8(text[:maxChars], "")
+		truncated = true
+	}
+
+	chunks := extract.Chunk(text, documentChunkSize)
+	parts := make([]string, 0, len(chunks))
+	for i, chunk := range chunks {
+		parts = append(parts, fmt.Sprintf("<document name=\"%s\" part=\"%d/%d\">\n%s\n</document>", file.FileName, i+1, len(chunks), chunk))
+	}
+	if truncated {
+		parts = append(parts, fmt.Sprintf("<document name=\"%s\">The document was truncated to fit the context window.</document>", file.FileName))
+	}
+	if len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("<document name=\"%s\">No text could be extracted from this file.</document>", file.FileName))
+	}
+	return parts, nil
+}
+
+// missingFile stands in for a file of the history that was deleted, so the
+// conversation can go on without it.
+func missingFile(fileName string) string {
+	return fmt.Sprintf("<document name=\"%s\">This file was deleted and is no longer available.</document>", fileName)
+}
+
+// knowledgeCitations numbers the retrieved chunks in the order they are given
+// to the model.
+func knowledgeCitations(hits []*model.KnowledgeHit) []Citation {
+	citations := make([]Citation, 0, len(hits))
+	for i, hit := range hits {
+		citations = append(citations, Citation{
+			Index:           i + 1,
+			Source:          consts.CitationSource.KnowledgeBase,
+			Title:           hit.FileName,
+			Snippet:         hit.Content,
+			KnowledgeBaseId: hit.KnowledgeBaseId,
+			DocumentId:      hit.DocumentId,
+			ChunkId:         hit.ChunkId,
+			Score:           hit.Score,
+		})
+	}
+	return citations
+}
+
+// knowledgePrompt renders the retrieved chunks as context for the prompt.
+func knowledgePrompt(citations []Citation) string {
+	sb := strings.Builder{}
+	sb.WriteString("<knowledge>\n")
+	for _, citation := range citations {
+		sb.WriteString(fmt.Sprintf("<source index=\"%d\" title=\"%s\">\n%s\n</source>\n", citation.Index, citation.Title, citation.Snippet))
+	}
+	sb.WriteString("</knowledge>\n")
+	sb.WriteString("Use the sources above when they are relevant to the question and cite them by index, like [1]. Ignore sources that are not relevant.")
+	return sb.String()
+}
+
+// saveImage stores an image generated by the model as an attachment of the
+// assistant message.
+func saveImage(ctx context.Context, userId string, messageId string, data []byte, mimeType string) (*ContentImage, error) {
+	if mimeType == "" {
+		mimeType = http.DetectContentType(data)
+	}
+	fileName := fmt.Sprintf("image-%s.%s", uuid.New().String()[:8], strings.TrimPrefix(mimeType, "image/"))
+	record, err := attachment.SaveBytes(ctx, userId, fileName, mimeType, data)
+	if err != nil {
+		return nil, err
+	}
+	if err = attachment.Bind(ctx, []*entity.Attachment{record}, messageId); err != nil {
+		return nil, err
+	}
+	return &ContentImage{
+		Id:       record.Id,
+		FileName: record.FileName,
+		MimeType: record.MimeType,
+		Size:     record.Size,
+	}, nil
+}
+
+func citationContent(citations []Citation) Content {
+	return Content{Type: consts.MessageType.Citation, Data: ContentCitation{Citations: citations}}
+}
+
+// runeOffset converts a byte offset into text to a character offset.
+func runeOffset(text string, offset int) int {
+	offset = max(0, min(offset, len(text)))
+	return utf8.RuneCountInString(text[:offset])
+}
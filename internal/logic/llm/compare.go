@@ -0,0 +1,77 @@
+package llm
+
+import (
+	"context"
+	"flai/internal/consts"
+	"flai/internal/logic"
+	"flai/internal/model/entity"
+	"sync"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/google/uuid"
+)
+
+// ChatModel is one of the models answering a prompt side by side. Alias is
+// the model alias it was routed from.
+type ChatModel struct {
+	ProviderInfo *logic.SimpleProviderInfo
+	ModelConfig  *logic.ModelConfig
+	Alias        string
+}
+
+// CompareChat streams the answers of several models to the same prompt
+// concurrently over one sink. Each answer starts with a meta_info event
+// naming its model and ends with a done or error event, all tagged by its
+// message id. [DONE] follows the last answer. The answers are stored as
+// sibling messages under the prompt; a failing model does not stop the
+// others.
+func CompareChat(ctx context.Context, sink Sink, models []*ChatModel, historyMessages []*entity.Message, newMessage *entity.Message, options *ChatOptions) {
+	if options == nil {
+		options = &ChatOptions{}
+	}
+	mux := &multiplexer{parent: sink}
+
+	// Announce all answers first, so clients can lay them out before the
+	// first token arrives
+	answers := make([]*ChatOptions, len(models))
+	sinks := make([]Sink, len(models))
+	for i, chatModel := range models {
+		answer := *options
+		answer.Alias = chatModel.Alias
+		answer.messageId = uuid.New().String()
+		answer.fallbackFrom = ""
+		answers[i] = &answer
+		sinks[i] = mux.sink()
+		_ = sinks[i].Send(StreamResponse{
+			MessageId: answer.messageId,
+			Type:      consts.MessageType.MetaInfo,
+			Data: MessageMetaInfo{
+				ProviderId:   chatModel.ProviderInfo.Id,
+				ProviderName: chatModel.ProviderInfo.Name,
+				ModelId:      chatModel.ModelConfig.ID,
+				ModelName:    chatModel.ModelConfig.Name,
+				Alias:        chatModel.Alias,
+			},
+		})
+	}
+
+	var wg sync.WaitGroup
+	for i, chatModel := range models {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := StreamChat(ctx, sinks[i], chatModel.ProviderInfo, chatModel.ModelConfig, historyMessages, newMessage, answers[i])
+			if err == nil {
+				return
+			}
+			g.Log().Errorf(ctx, "Failed to stream answer of model %s: %v", chatModel.ModelConfig.Name, err)
+			_ = sinks[i].Send(StreamResponse{
+				MessageId: answers[i].messageId,
+				Type:      consts.MessageType.Error,
+				Data:      ContentError{Message: err.Error()},
+			})
+		}()
+	}
+	wg.Wait()
+	mux.close()
+}
@@ -1,24 +1,28 @@
 package llm
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"flai/internal/consts"
-	"flai/internal/dao"
 	"flai/internal/logic"
+	"flai/internal/logic/attachment"
 	"flai/internal/model/entity"
+	"slices"
 	"strings"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
-	"github.com/gogf/gf/v2/net/ghttp"
 	"github.com/google/uuid"
 	"google.golang.org/genai"
 )
 
+// geminiInlineDataLimit keeps inline attachments below the 20MB request cap.
+const geminiInlineDataLimit = 14 << 20
+
 type GeminiClient struct{}
 
 func (geminiClient *GeminiClient) getClient(ctx context.Context, providerInfo *logic.SimpleProviderInfo) (*genai.Client, error) {
@@ -37,7 +41,7 @@ func (geminiClient *GeminiClient) getClient(ctx context.Context, providerInfo *l
 		})
 	}
 }
-func (geminiClient *GeminiClient) StreamChat(ctx context.Context, response *ghttp.Response, providerInfo *logic.SimpleProviderInfo, modelConfig *logic.ModelConfig, historyMessages []*entity.Message, newMessage *entity.Message, tools []string) error {
+func (geminiClient *GeminiClient) StreamChat(ctx context.Context, sink Sink, providerInfo *logic.SimpleProviderInfo, modelConfig *logic.ModelConfig, historyMessages []*entity.Message, newMessage *entity.Message, options *ChatOptions) error {
 	client, err := geminiClient.getClient(ctx, providerInfo)
 	if err != nil {
 		return err
@@ -45,33 +49,24 @@ func (geminiClient *GeminiClient) StreamChat(ctx context.Context, response *ghtt
 
 	// Convert historyMessages to genai.Message format
 	var history []*genai.Content
-	if len(historyMessages) > 0 {
-		for _, msg := range historyMessages {
-			var role genai.Role
-			role = genai.RoleUser
-			if msg.Role == consts.MessageRole.Assistant {
-				role = genai.RoleModel
-			}
-			var contents []Content
-			err := json.Unmarshal([]byte(msg.Content), &contents)
-			if err != nil {
-				return err
-			}
-			for _, content := range contents {
-				if content.Type == consts.MessageType.Message {
-					var data ContentMessage
-					err := mapstructure.Decode(content.Data, &data)
-					if err != nil {
-						return err
-					}
-					history = append(history, genai.NewContentFromText(data.Content, role))
-				}
-			}
+	for _, msg := range historyMessages {
+		role := genai.Role(genai.RoleUser)
+		if msg.Role == consts.MessageRole.Assistant {
+			role = genai.RoleModel
 		}
+		parts, err := geminiClient.buildParts(ctx, client, modelConfig, options.UserId, msg, replayMetaInfo(msg, providerInfo, modelConfig))
+		if err != nil {
+			return err
+		}
+		history = append(history, splitTurns(parts, role)...)
+	}
+	newParts, err := geminiClient.buildParts(ctx, client, modelConfig, options.UserId, newMessage, nil)
+	if err != nil {
+		return err
 	}
 	var genaiTools = []*genai.Tool{}
-	if tools != nil {
-		for _, tool := range tools {
+	if options.Tools != nil {
+		for _, tool := range options.Tools {
 			if tool == consts.InternalTools.InternalWebSearch {
 				genaiTools = append(genaiTools, &genai.Tool{
 					GoogleSearch: &genai.GoogleSearch{},
@@ -79,12 +74,52 @@ func (geminiClient *GeminiClient) StreamChat(ctx context.Context, response *ghtt
 			}
 		}
 	}
+	var functionDeclarations []*genai.FunctionDeclaration
+	for _, tool := range functionTools(modelConfig, options.Tools) {
+		functionDeclarations = append(functionDeclarations, &genai.FunctionDeclaration{
+			Name:                 tool.Name,
+			Description:          tool.Description,
+			ParametersJsonSchema: tool.Parameters,
+		})
+	}
+	if len(functionDeclarations) > 0 {
+		genaiTools = append(genaiTools, &genai.Tool{
+			FunctionDeclarations: functionDeclarations,
+		})
+	}
 	var config = &genai.GenerateContentConfig{
 		ThinkingConfig: &genai.ThinkingConfig{
 			IncludeThoughts: true,
+			ThinkingBudget:  thinkingBudget(options.Settings),
 		},
 		Tools: genaiTools,
 	}
+	if slices.Contains(modelConfig.Modalities.Output, consts.Modality.Image) {
+		config.ResponseModalities = []string{string(genai.ModalityText), string(genai.ModalityImage)}
+	}
+	var systemInstruction []string
+	if settings := options.Settings; settings != nil {
+		if settings.SystemPrompt != "" {
+			systemInstruction = append(systemInstruction, settings.SystemPrompt)
+		}
+		if settings.Temperature != nil {
+			config.Temperature = genai.Ptr(float32(*settings.Temperature))
+		}
+		if settings.TopP != nil {
+			config.TopP = genai.Ptr(float32(*settings.TopP))
+		}
+		if settings.MaxOutputTokens != nil {
+			config.MaxOutputTokens = int32(*settings.MaxOutputTokens)
+		}
+		config.StopSequences = settings.StopSequences
+	}
+	citations := knowledgeCitations(options.Knowledge)
+	if len(citations) > 0 {
+		systemInstruction = append(systemInstruction, knowledgePrompt(citations))
+	}
+	if len(systemInstruction) > 0 {
+		config.SystemInstruction = genai.NewContentFromText(strings.Join(systemInstruction, "\n\n"), genai.RoleUser)
+	}
 
 	chat, err := client.Chats.Create(ctx, modelConfig.ID, config, history)
 	if err != nil {
@@ -92,12 +127,16 @@ func (geminiClient *GeminiClient) StreamChat(ctx context.Context, response *ghtt
 	}
 
 	// Send the last message
-	iter := chat.SendMessageStream(ctx, genai.Part{Text: newMessage.Content})
+	var sendParts []genai.Part
+	for _, part := range newParts {
+		sendParts = append(sendParts, *part)
+	}
+	iter := chat.SendMessageStream(ctx, sendParts...)
 
 	var currentMessageType string
 	var currentContentBuilder strings.Builder
 	var contentList []Content
-	messageId := uuid.New().String()
+	messageId := options.messageId
 	conversationId := newMessage.ConversationId
 	message := entity.Message{
 		Id:             messageId,
@@ -106,77 +145,216 @@ func (geminiClient *GeminiClient) StreamChat(ctx context.Context, response *ghtt
 		Role:           consts.MessageRole.Assistant,
 	}
 	messageMetaInfo := MessageMetaInfo{
+		ProviderId:   providerInfo.Id,
 		ProviderName: providerInfo.Name,
+		ModelId:      modelConfig.ID,
 		ModelName:    modelConfig.Name,
+		FallbackFrom: options.fallbackFrom,
+		Alias:        options.Alias,
+	}
+	if settings := options.Settings; settings != nil {
+		messageMetaInfo.ReasoningEffort = settings.ReasoningEffort
+		messageMetaInfo.ThinkingBudget = settings.ThinkingBudget
 	}
+	citationCount := len(citations)
 
+	// Thought signatures are kept on the block they were sent with: the text
+	// block being built, an image or a function call
+	var currentSignature string
+	callSignatures := make(map[string]string)
+	flushBlock := func() {
+		blockCount := len(contentList)
+		appendContent(&currentContentBuilder, currentMessageType, &contentList)
+		if len(contentList) > blockCount {
+			contentList[blockCount].Signature = currentSignature
+		}
+		currentContentBuilder.Reset()
+		currentSignature = ""
+	}
+
+	store := newAnswerStore(ctx, message)
 	saveMessage := func(ctx context.Context) {
 		if currentMessageType != "" && currentContentBuilder.Len() > 0 {
-			appendContent(&currentContentBuilder, currentMessageType, &contentList)
-		}
-		contentListByte, err := json.Marshal(contentList)
-		if err != nil {
-			g.Log().Errorf(ctx, "Failed to marshal content list: %v", err)
-			return
+			flushBlock()
 		}
-		message.Content = string(contentListByte)
-		messageMetaInfoByte, err := json.Marshal(messageMetaInfo)
-		if err != nil {
-			g.Log().Errorf(ctx, "Failed to marshal meta info: %v", err)
+		store.save(ctx, contentList, messageMetaInfo)
+	}
+	// checkpoint saves what was streamed so far, including the block being
+	// built
+	checkpoint := func() {
+		if !store.due() {
 			return
 		}
-		message.MetaInfo = string(messageMetaInfoByte)
-		_, err = dao.Message.Ctx(ctx).Data(message).Insert()
-		if err != nil {
-			g.Log().Errorf(ctx, "Failed to save message: %v", err)
+		contents := slices.Clone(contentList)
+		appendContent(&currentContentBuilder, currentMessageType, &contents)
+		if len(contents) > len(contentList) {
+			contents[len(contentList)].Signature = currentSignature
 		}
+		store.save(context.WithoutCancel(ctx), contents, messageMetaInfo)
 	}
 
-	for resp, err := range iter {
-		if err != nil {
-			if errors.Is(ctx.Err(), context.Canceled) {
-				saveMessage(context.WithoutCancel(ctx))
-				return nil
+	// The knowledge citations were already streamed by StreamChat
+	if len(citations) > 0 {
+		contentList = append(contentList, citationContent(citations))
+	}
+
+	// Each step streams one response. Function calls made by the model are
+	// executed and answered until it responds without calling a tool.
+	maxSteps := maxToolIterations(ctx)
+	for step := 0; ; step++ {
+		stepUsage := messageMetaInfo
+		var calls []*ContentFunctionCall
+		for resp, err := range iter {
+			if err != nil {
+				if errors.Is(ctx.Err(), context.Canceled) {
+					saveMessage(context.WithoutCancel(ctx))
+					return nil
+				}
+				return err
 			}
-			return err
-		}
 
-		for _, candidate := range resp.Candidates {
-			var thoughtSignature []byte
-			if candidate.Content != nil {
-				for _, part := range candidate.Content.Parts {
-					if part.Text != "" {
-						partType := consts.MessageType.Message
-						if part.Thought {
-							partType = consts.MessageType.Reasoning
-						}
+			for _, candidate := range resp.Candidates {
+				var thoughtSignature []byte
+				if candidate.Content != nil {
+					for _, part := range candidate.Content.Parts {
+						if part.Text != "" {
+							partType := consts.MessageType.Message
+							if part.Thought {
+								partType = consts.MessageType.Reasoning
+							}
+
+							messageMetaInfo.CachedTokenCount = stepUsage.CachedTokenCount + int(resp.UsageMetadata.CachedContentTokenCount)
+							messageMetaInfo.PromptTokenCount = stepUsage.PromptTokenCount + int(resp.UsageMetadata.PromptTokenCount)
+							messageMetaInfo.ReasoningTokenCount = stepUsage.ReasoningTokenCount + int(resp.UsageMetadata.ThoughtsTokenCount)
+							messageMetaInfo.ResponseTokenCount = stepUsage.ResponseTokenCount + int(resp.UsageMetadata.CandidatesTokenCount)
+							messageMetaInfo.ToolUseTokenCount = stepUsage.ToolUseTokenCount + int(resp.UsageMetadata.ToolUsePromptTokenCount)
+
+							// If type switched, save previous block
+							if currentMessageType != "" && currentMessageType != partType {
+								flushBlock()
+							}
+
+							currentMessageType = partType
+							currentContentBuilder.WriteString(part.Text)
 
-						messageMetaInfo.CachedTokenCount = int(resp.UsageMetadata.CachedContentTokenCount)
-						messageMetaInfo.PromptTokenCount = int(resp.UsageMetadata.PromptTokenCount)
-						messageMetaInfo.ReasoningTokenCount = int(resp.UsageMetadata.ThoughtsTokenCount)
-						messageMetaInfo.ResponseTokenCount = int(resp.UsageMetadata.CandidatesTokenCount)
-						messageMetaInfo.ToolUseTokenCount = int(resp.UsageMetadata.ToolUsePromptTokenCount)
+							streamResponse := StreamResponse{
+								MessageId: messageId,
+								Type:      partType,
+							}
+							if partType == consts.MessageType.Reasoning {
+								streamResponse.Data = ContentReasoning{Content: part.Text}
+							} else {
+								streamResponse.Data = ContentMessage{Content: part.Text}
+							}
 
-						// If type switched, save previous block
-						if currentMessageType != "" && currentMessageType != partType {
-							appendContent(&currentContentBuilder, currentMessageType, &contentList)
-							currentContentBuilder.Reset()
+							err := sink.Send(streamResponse)
+							if err != nil {
+								if errors.Is(ctx.Err(), context.Canceled) {
+									saveMessage(context.WithoutCancel(ctx))
+									return nil
+								}
+								return err
+							}
 						}
+						if part.InlineData != nil && !part.Thought && strings.HasPrefix(part.InlineData.MIMEType, "image/") {
+							flushBlock()
+							currentMessageType = ""
 
-						currentMessageType = partType
-						currentContentBuilder.WriteString(part.Text)
+							image, err := saveImage(ctx, options.UserId, messageId, part.InlineData.Data, part.InlineData.MIMEType)
+							if err != nil {
+								return err
+							}
+							contentList = append(contentList, Content{
+								Type:      consts.MessageType.Image,
+								Data:      image,
+								Signature: encodeSignature(part.ThoughtSignature),
+							})
+							err = sink.Send(StreamResponse{
+								MessageId: messageId,
+								Type:      consts.MessageType.Image,
+								Data:      image,
+							})
+							if err != nil {
+								if errors.Is(ctx.Err(), context.Canceled) {
+									saveMessage(context.WithoutCancel(ctx))
+									return nil
+								}
+								return err
+							}
+						}
+						if len(part.ThoughtSignature) > 0 {
+							thoughtSignature = part.ThoughtSignature
+							if part.FunctionCall == nil && part.InlineData == nil {
+								currentSignature = encodeSignature(part.ThoughtSignature)
+							}
+						}
+						if part.FunctionCall != nil {
+							flushBlock()
+							currentMessageType = ""
 
-						streamResponse := StreamResponse{
-							MessageId: messageId,
-							Type:      partType,
+							arguments, err := json.Marshal(part.FunctionCall.Args)
+							if err != nil {
+								return err
+							}
+							call := &ContentFunctionCall{
+								Id:        part.FunctionCall.ID,
+								Name:      part.FunctionCall.Name,
+								Arguments: string(arguments),
+							}
+							if call.Id == "" {
+								call.Id = uuid.New().String()
+							}
+							callSignatures[call.Id] = encodeSignature(part.ThoughtSignature)
+							calls = append(calls, call)
+							err = sink.Send(StreamResponse{
+								MessageId: messageId,
+								Type:      consts.MessageType.FunctionCall,
+								Data:      call,
+							})
+							if err != nil {
+								if errors.Is(ctx.Err(), context.Canceled) {
+									saveMessage(context.WithoutCancel(ctx))
+									return nil
+								}
+								return err
+							}
 						}
-						if partType == consts.MessageType.Reasoning {
-							streamResponse.Data = ContentReasoning{Content: part.Text}
-						} else {
-							streamResponse.Data = ContentMessage{Content: part.Text}
+					}
+				}
+				if candidate.FinishReason == genai.FinishReasonStop {
+					messageMetaInfo.ThoughtSignature = base64.StdEncoding.EncodeToString(thoughtSignature)
+					streamResponse := StreamResponse{
+						MessageId: messageId,
+						Type:      consts.MessageType.MetaInfo,
+						Data:      messageMetaInfo,
+					}
+					err := sink.Send(streamResponse)
+					if err != nil {
+						if errors.Is(ctx.Err(), context.Canceled) {
+							saveMessage(context.WithoutCancel(ctx))
+							return nil
 						}
+						return err
+					}
 
-						err := StreamToClient(response, streamResponse)
+					// Grounding spans refer to the answer text of this step,
+					// so the citation block has to follow it directly.
+					var answer string
+					if currentMessageType == consts.MessageType.Message {
+						answer = currentContentBuilder.String()
+					}
+					groundingCitations := groundingCitations(candidate.GroundingMetadata, answer, citationCount)
+					if len(groundingCitations) > 0 {
+						flushBlock()
+						currentMessageType = ""
+						citationCount += len(groundingCitations)
+						contentList = append(contentList, citationContent(groundingCitations))
+						streamResponse := StreamResponse{
+							MessageId: messageId,
+							Type:      consts.MessageType.Citation,
+							Data:      ContentCitation{Citations: groundingCitations},
+						}
+						err := sink.Send(streamResponse)
 						if err != nil {
 							if errors.Is(ctx.Err(), context.Canceled) {
 								saveMessage(context.WithoutCancel(ctx))
@@ -185,19 +363,36 @@ func (geminiClient *GeminiClient) StreamChat(ctx context.Context, response *ghtt
 							return err
 						}
 					}
-					if len(part.ThoughtSignature) > 0 {
-						thoughtSignature = part.ThoughtSignature
-					}
 				}
 			}
-			if candidate.FinishReason == genai.FinishReasonStop {
-				messageMetaInfo.ThoughtSignature = base64.StdEncoding.EncodeToString(thoughtSignature)
-				streamResponse := StreamResponse{
+			checkpoint()
+		}
+		if len(calls) == 0 {
+			break
+		}
+
+		var responseParts []genai.Part
+		for _, call := range calls {
+			var callCitations []Citation
+			var callFiles []ToolFile
+			if step < maxSteps {
+				callCitations, callFiles = executeTool(ctx, call, citationCount)
+			} else {
+				refuseTool(call)
+			}
+			contentList = append(contentList, Content{
+				Type:      consts.MessageType.FunctionCall,
+				Data:      call,
+				Signature: callSignatures[call.Id],
+			})
+			if len(callCitations) > 0 {
+				citationCount += len(callCitations)
+				contentList = append(contentList, citationContent(callCitations))
+				err := sink.Send(StreamResponse{
 					MessageId: messageId,
-					Type:      consts.MessageType.MetaInfo,
-					Data:      messageMetaInfo,
-				}
-				err := StreamToClient(response, streamResponse)
+					Type:      consts.MessageType.Citation,
+					Data:      ContentCitation{Citations: callCitations},
+				})
 				if err != nil {
 					if errors.Is(ctx.Err(), context.Canceled) {
 						saveMessage(context.WithoutCancel(ctx))
@@ -205,33 +400,236 @@ func (geminiClient *GeminiClient) StreamChat(ctx context.Context, response *ghtt
 					}
 					return err
 				}
-
-				if candidate.GroundingMetadata != nil && len(candidate.GroundingMetadata.GroundingChunks) > 0 {
-					messageMetaInfo.GoogleGroundingData = candidate.GroundingMetadata
-					streamResponse := StreamResponse{
-						MessageId: messageId,
-						Type:      consts.MessageType.GoogleGroundingData,
-						Data:      candidate.GroundingMetadata,
-					}
-					err := StreamToClient(response, streamResponse)
-					if err != nil {
-						if errors.Is(ctx.Err(), context.Canceled) {
-							saveMessage(context.WithoutCancel(ctx))
-							return nil
-						}
-						return err
+			}
+			for _, file := range saveToolFiles(ctx, call, callFiles, options.UserId, messageId) {
+				contentList = append(contentList, Content{Type: consts.MessageType.Attachment, Data: file})
+				err := sink.Send(StreamResponse{
+					MessageId: messageId,
+					Type:      consts.MessageType.Attachment,
+					Data:      file,
+				})
+				if err != nil {
+					if errors.Is(ctx.Err(), context.Canceled) {
+						saveMessage(context.WithoutCancel(ctx))
+						return nil
 					}
+					return err
 				}
 			}
+			responseParts = append(responseParts, genai.Part{FunctionResponse: functionResponse(call)})
+			err := sink.Send(StreamResponse{
+				MessageId: messageId,
+				Type:      consts.MessageType.FunctionCall,
+				Data:      call,
+			})
+			if err != nil {
+				if errors.Is(ctx.Err(), context.Canceled) {
+					saveMessage(context.WithoutCancel(ctx))
+					return nil
+				}
+				return err
+			}
+		}
+		// Refused calls get one more request without function calling, so
+		// the model answers with what it already has
+		if step > maxSteps {
+			break
 		}
+		if step == maxSteps {
+			config.ToolConfig = &genai.ToolConfig{
+				FunctionCallingConfig: &genai.FunctionCallingConfig{Mode: genai.FunctionCallingConfigModeNone},
+			}
+		}
+		iter = chat.SendMessageStream(ctx, responseParts...)
 	}
 
 	saveMessage(context.WithoutCancel(ctx))
-	response.Writef("data: [DONE]\n\n")
-	response.Flush()
+	sink.Done(messageId)
 	return err
 }
 
+// buildParts converts a stored message into genai parts. Attachments are sent
+// inline, or through the Files API when they are too large for a request.
+// Files the model cannot read natively are sent as extracted text. Generated
+// images are sent back so image models can keep editing them. Answers of the
+// same model, given by replay, also carry their thought signatures and
+// function calls, each call batch followed by its responses. Only files of
+// the user are loaded.
+func (geminiClient *GeminiClient) buildParts(ctx context.Context, client *genai.Client, modelConfig *logic.ModelConfig, userId string, msg *entity.Message, replay *MessageMetaInfo) ([]*genai.Part, error) {
+	contents, err := decodeContents(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	var parts []*genai.Part
+	var responseParts []*genai.Part
+	signed := false
+	sign := func(part *genai.Part, signature string) *genai.Part {
+		if replay != nil && signature != "" {
+			part.ThoughtSignature = decodeSignature(signature)
+			signed = true
+		}
+		return part
+	}
+	// Calls the model made in a row are sent together, followed by their
+	// responses
+	addPart := func(part *genai.Part, signature string) {
+		parts = append(parts, responseParts...)
+		responseParts = nil
+		parts = append(parts, sign(part, signature))
+	}
+	for _, content := range contents {
+		switch content.Type {
+		case consts.MessageType.Message:
+			var data ContentMessage
+			if err := mapstructure.Decode(content.Data, &data); err != nil {
+				return nil, err
+			}
+			addPart(genai.NewPartFromText(data.Content), content.Signature)
+		case consts.MessageType.Reasoning:
+			if replay == nil || content.Signature == "" {
+				continue
+			}
+			var data ContentReasoning
+			if err := mapstructure.Decode(content.Data, &data); err != nil {
+				return nil, err
+			}
+			addPart(&genai.Part{Text: data.Content, Thought: true}, content.Signature)
+		case consts.MessageType.FunctionCall:
+			if replay == nil {
+				continue
+			}
+			var data ContentFunctionCall
+			if err := mapstructure.Decode(content.Data, &data); err != nil {
+				return nil, err
+			}
+			var args map[string]any
+			if err := json.Unmarshal([]byte(data.Arguments), &args); err != nil {
+				return nil, err
+			}
+			parts = append(parts, sign(&genai.Part{
+				FunctionCall: &genai.FunctionCall{ID: data.Id, Name: data.Name, Args: args},
+			}, content.Signature))
+			responseParts = append(responseParts, &genai.Part{FunctionResponse: functionResponse(&data)})
+		case consts.MessageType.Image:
+			var data ContentImage
+			if err := mapstructure.Decode(content.Data, &data); err != nil {
+				return nil, err
+			}
+			file, fileData, err := attachment.Load(ctx, userId, data.Id)
+			if errors.Is(err, attachment.ErrNotFound) {
+				addPart(genai.NewPartFromText(missingFile(data.FileName)), content.Signature)
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			addPart(genai.NewPartFromBytes(fileData, file.MimeType), content.Signature)
+		case consts.MessageType.Attachment:
+			if msg.Role == consts.MessageRole.Assistant {
+				continue
+			}
+			var data ContentAttachment
+			if err := mapstructure.Decode(content.Data, &data); err != nil {
+				return nil, err
+			}
+			file, fileData, err := attachment.Load(ctx, userId, data.Id)
+			if errors.Is(err, attachment.ErrNotFound) {
+				parts = append(parts, genai.NewPartFromText(missingFile(data.FileName)))
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if !attachment.SendNatively(modelConfig, file) {
+				texts, err := documentContext(ctx, modelConfig, file, fileData)
+				if err != nil {
+					return nil, err
+				}
+				for _, text := range texts {
+					parts = append(parts, genai.NewPartFromText(text))
+				}
+				continue
+			}
+			if len(fileData) <= geminiInlineDataLimit {
+				parts = append(parts, genai.NewPartFromBytes(fileData, file.MimeType))
+				continue
+			}
+			uploaded, err := client.Files.Upload(ctx, bytes.NewReader(fileData), &genai.UploadFileConfig{
+				MIMEType:    file.MimeType,
+				DisplayName: file.FileName,
+			})
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, genai.NewPartFromURI(uploaded.URI, file.MimeType))
+		}
+	}
+	parts = append(parts, responseParts...)
+
+	// Messages saved before signatures were stored per block only kept the
+	// last one, which belongs to the end of the answer
+	if replay != nil && !signed && replay.ThoughtSignature != "" {
+		for i := len(parts) - 1; i >= 0; i-- {
+			if parts[i].Text != "" && !parts[i].Thought {
+				parts[i].ThoughtSignature = decodeSignature(replay.ThoughtSignature)
+				break
+			}
+		}
+	}
+	return parts, nil
+}
+
+// splitTurns groups the parts of a message into contents of the role. The
+// function responses of a replayed answer are sent as user turns in between.
+func splitTurns(parts []*genai.Part, role genai.Role) []*genai.Content {
+	var contents []*genai.Content
+	for _, part := range parts {
+		partRole := role
+		if part.FunctionResponse != nil {
+			partRole = genai.RoleUser
+		}
+		if n := len(contents); n > 0 && contents[n-1].Role == string(partRole) {
+			contents[n-1].Parts = append(contents[n-1].Parts, part)
+			continue
+		}
+		contents = append(contents, genai.NewContentFromParts([]*genai.Part{part}, partRole))
+	}
+	return contents
+}
+
+// functionResponse converts the result of a tool call into the response the
+// model expects.
+func functionResponse(call *ContentFunctionCall) *genai.FunctionResponse {
+	result := map[string]any{"output": call.Result}
+	if call.IsError {
+		result = map[string]any{"error": call.Result}
+	}
+	return &genai.FunctionResponse{
+		ID:       call.Id,
+		Name:     call.Name,
+		Response: result,
+	}
+}
+
+// encodeSignature stores a thought signature as text.
+func encodeSignature(signature []byte) string {
+	if len(signature) == 0 {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(signature)
+}
+
+// decodeSignature restores a stored thought signature. Invalid signatures
+// are dropped rather than failing the request.
+func decodeSignature(signature string) []byte {
+	data, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
 func (geminiClient *GeminiClient) GenerateTitle(ctx context.Context, providerInfo *logic.SimpleProviderInfo, modelConfig *logic.ModelConfig, systemInstruction string, content string) (*TitleGenerationResponse, error) {
 	client, err := geminiClient.getClient(ctx, providerInfo)
 	if err != nil {
@@ -291,3 +689,68 @@ func (geminiClient *GeminiClient) GenerateTitle(ctx context.Context, providerInf
 
 	return nil, gerror.New("Failed to generate title")
 }
+
+func (geminiClient *GeminiClient) Embed(ctx context.Context, providerInfo *logic.SimpleProviderInfo, model string, inputs []string) ([][]float32, error) {
+	client, err := geminiClient.getClient(ctx, providerInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	contents := make([]*genai.Content, 0, len(inputs))
+	for _, input := range inputs {
+		contents = append(contents, genai.NewContentFromText(input, genai.RoleUser))
+	}
+	resp, err := client.Models.EmbedContent(ctx, model, contents, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings := make([][]float32, 0, len(resp.Embeddings))
+	for _, embedding := range resp.Embeddings {
+		embeddings = append(embeddings, embedding.Values)
+	}
+	return embeddings, nil
+}
+
+// groundingCitations converts the web sources of Google Search grounding into
+// citations. Segment offsets are bytes into answer and are converted to
+// character offsets.
+func groundingCitations(metadata *genai.GroundingMetadata, answer string, citationCount int) []Citation {
+	if metadata == nil {
+		return nil
+	}
+	var citations []Citation
+	chunkCitation := make(map[int]int)
+	for i, chunk := range metadata.GroundingChunks {
+		if chunk == nil || chunk.Web == nil || chunk.Web.URI == "" {
+			continue
+		}
+		title := chunk.Web.Title
+		if title == "" {
+			title = chunk.Web.Domain
+		}
+		chunkCitation[i] = len(citations)
+		citations = append(citations, Citation{
+			Index:  citationCount + len(citations) + 1,
+			Source: consts.CitationSource.Web,
+			Title:  title,
+			Url:    chunk.Web.URI,
+		})
+	}
+	for _, support := range metadata.GroundingSupports {
+		if support == nil || support.Segment == nil {
+			continue
+		}
+		span := CitationSpan{
+			Start: runeOffset(answer, int(support.Segment.StartIndex)),
+			End:   runeOffset(answer, int(support.Segment.EndIndex)),
+			Text:  support.Segment.Text,
+		}
+		for _, chunkIndex := range support.GroundingChunkIndices {
+			if i, ok := chunkCitation[int(chunkIndex)]; ok {
+				citations[i].Spans = append(citations[i].Spans, span)
+			}
+		}
+	}
+	return citations
+}
@@ -2,18 +2,18 @@ package llm
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"flai/internal/consts"
-	"flai/internal/dao"
 	"flai/internal/logic"
+	"flai/internal/logic/attachment"
 	"flai/internal/model/entity"
+	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/go-viper/mapstructure/v2"
-	"github.com/gogf/gf/v2/frame/g"
-	"github.com/gogf/gf/v2/net/ghttp"
-	"github.com/google/uuid"
 	openai "github.com/openai/openai-go/v3"
 	"github.com/openai/openai-go/v3/option"
 	"github.com/openai/openai-go/v3/responses"
@@ -32,65 +32,85 @@ func (c *OpenAIClient) getClient(ctx context.Context, providerInfo *logic.Simple
 	return openai.NewClient(opts...)
 }
 
-func (c *OpenAIClient) StreamChat(ctx context.Context, response *ghttp.Response, providerInfo *logic.SimpleProviderInfo, modelConfig *logic.ModelConfig, historyMessages []*entity.Message, newMessage *entity.Message, tools []string) error {
+func (c *OpenAIClient) StreamChat(ctx context.Context, sink Sink, providerInfo *logic.SimpleProviderInfo, modelConfig *logic.ModelConfig, historyMessages []*entity.Message, newMessage *entity.Message, options *ChatOptions) error {
 	client := c.getClient(ctx, providerInfo)
 
 	var inputItems []responses.ResponseInputItemUnionParam
-
-	if len(historyMessages) > 0 {
-		for _, msg := range historyMessages {
-			var contents []Content
-			err := json.Unmarshal([]byte(msg.Content), &contents)
-			if err != nil {
-				return err
-			}
-			role := responses.EasyInputMessageRoleUser
-			if msg.Role == consts.MessageRole.Assistant {
-				role = responses.EasyInputMessageRoleAssistant
-			}
-
-			for _, content := range contents {
-				if content.Type == consts.MessageType.Message {
-					var data ContentMessage
-					if err := mapstructure.Decode(content.Data, &data); err != nil {
-						return err
-					}
-					inputItems = append(inputItems, responses.ResponseInputItemParamOfMessage(data.Content, role))
-				}
-			}
+	for _, msg := range historyMessages {
+		items, err := c.buildInputItems(ctx, providerInfo, modelConfig, options.UserId, msg)
+		if err != nil {
+			return err
 		}
+		inputItems = append(inputItems, items...)
 	}
-
-	inputItems = append(inputItems, responses.ResponseInputItemParamOfMessage(newMessage.Content, responses.EasyInputMessageRoleUser))
-
-	input := responses.ResponseNewParamsInputUnion{
-		OfInputItemList: inputItems,
+	citations := knowledgeCitations(options.Knowledge)
+	if len(citations) > 0 {
+		inputItems = append(inputItems, responses.ResponseInputItemParamOfMessage(knowledgePrompt(citations), responses.EasyInputMessageRoleDeveloper))
 	}
+	newItems, err := c.buildInputItems(ctx, providerInfo, modelConfig, options.UserId, newMessage)
+	if err != nil {
+		return err
+	}
+	inputItems = append(inputItems, newItems...)
 
 	openaiTools := []responses.ToolUnionParam{}
-	for _, tool := range tools {
-		if tool == consts.InternalTools.InternalWebSearch {
+	for _, tool := range options.Tools {
+		switch tool {
+		case consts.InternalTools.InternalWebSearch:
 			openaiTools = append(openaiTools, responses.ToolUnionParam{
 				OfWebSearch: &responses.WebSearchToolParam{
 					Type: responses.WebSearchToolTypeWebSearch,
 				},
 			})
+		case consts.InternalTools.InternalImageGeneration:
+			openaiTools = append(openaiTools, responses.ToolUnionParam{
+				OfImageGeneration: &responses.ToolImageGenerationParam{},
+			})
 		}
 	}
+	for _, tool := range functionTools(modelConfig, options.Tools) {
+		openaiTools = append(openaiTools, responses.ToolUnionParam{
+			OfFunction: &responses.FunctionToolParam{
+				Name:        tool.Name,
+				Description: openai.String(tool.Description),
+				Parameters:  tool.Parameters,
+				Strict:      openai.Bool(false),
+			},
+		})
+	}
 	params := responses.ResponseNewParams{
 		Model: modelConfig.ID,
-		Input: input,
 		Reasoning: shared.ReasoningParam{
 			Summary: shared.ReasoningSummaryAuto,
 		},
 		Tools: openaiTools,
 	}
-	stream := client.Responses.NewStreaming(ctx, params)
+	if modelConfig.Reasoning {
+		// Encrypted reasoning is stored so later turns can replay it
+		params.Include = []responses.ResponseIncludable{responses.ResponseIncludableReasoningEncryptedContent}
+	}
+	if settings := options.Settings; settings != nil {
+		if settings.SystemPrompt != "" {
+			params.Instructions = openai.String(settings.SystemPrompt)
+		}
+		if settings.Temperature != nil {
+			params.Temperature = openai.Float(*settings.Temperature)
+		}
+		if settings.TopP != nil {
+			params.TopP = openai.Float(*settings.TopP)
+		}
+		if effort := reasoningEffort(settings); effort != "" {
+			params.Reasoning.Effort = shared.ReasoningEffort(effort)
+		}
+		if settings.MaxOutputTokens != nil {
+			params.MaxOutputTokens = openai.Int(int64(*settings.MaxOutputTokens))
+		}
+	}
 
 	var currentContentBuilder strings.Builder
 	var contentList []Content
 	var contentType string
-	messageId := uuid.New().String()
+	messageId := options.messageId
 	conversationId := newMessage.ConversationId
 	message := entity.Message{
 		Id:             messageId,
@@ -99,94 +119,470 @@ func (c *OpenAIClient) StreamChat(ctx context.Context, response *ghttp.Response,
 		Role:           consts.MessageRole.Assistant,
 	}
 	messageMetaInfo := MessageMetaInfo{
+		ProviderId:   providerInfo.Id,
 		ProviderName: providerInfo.Name,
+		ModelId:      modelConfig.ID,
 		ModelName:    modelConfig.Name,
+		FallbackFrom: options.fallbackFrom,
+		Alias:        options.Alias,
+	}
+	if settings := options.Settings; settings != nil {
+		messageMetaInfo.ReasoningEffort = settings.ReasoningEffort
+		messageMetaInfo.ThinkingBudget = settings.ThinkingBudget
 	}
+	citationCount := len(citations)
 
+	store := newAnswerStore(ctx, message)
 	saveMessage := func(ctx context.Context) {
 		appendContent(&currentContentBuilder, contentType, &contentList)
-		contentListByte, err := json.Marshal(contentList)
-		if err != nil {
-			g.Log().Errorf(ctx, "Failed to marshal content list: %v", err)
-			return
-		}
-		message.Content = string(contentListByte)
-		messageMetaInfoByte, err := json.Marshal(messageMetaInfo)
-		if err != nil {
-			g.Log().Errorf(ctx, "Failed to marshal meta info: %v", err)
+		store.save(ctx, contentList, messageMetaInfo)
+	}
+	// checkpoint saves what was streamed so far, including the block being
+	// built
+	checkpoint := func() {
+		if !store.due() {
 			return
 		}
-		message.MetaInfo = string(messageMetaInfoByte)
-		_, err = dao.Message.Ctx(ctx).Data(message).Insert()
-		if err != nil {
-			g.Log().Errorf(ctx, "Failed to save message: %v", err)
-		}
+		contents := slices.Clone(contentList)
+		appendContent(&currentContentBuilder, contentType, &contents)
+		store.save(context.WithoutCancel(ctx), contents, messageMetaInfo)
+	}
+
+	// The knowledge citations were already streamed by StreamChat
+	if len(citations) > 0 {
+		contentList = append(contentList, citationContent(citations))
 	}
 
-	for stream.Next() {
-		event := stream.Current()
-		streamResponse := StreamResponse{
-			MessageId: messageId,
+	// Each step streams one response. Function calls made by the model are
+	// executed and sent back until it answers without calling a tool.
+	maxSteps := maxToolIterations(ctx)
+	for step := 0; ; step++ {
+		params.Input = responses.ResponseNewParamsInputUnion{
+			OfInputItemList: inputItems,
 		}
-		switch e := event.AsAny().(type) {
-		case responses.ResponseReasoningSummaryTextDeltaEvent:
-			if e.Delta != "" {
+		// Retries are left to StreamChat, which knows whether output was
+		// already streamed
+		stream := client.Responses.NewStreaming(ctx, params, option.WithMaxRetries(0))
+
+		var calls []*ContentFunctionCall
+		for stream.Next() {
+			event := stream.Current()
+			streamResponse := StreamResponse{
+				MessageId: messageId,
+			}
+			switch e := event.AsAny().(type) {
+			case responses.ResponseReasoningSummaryTextDeltaEvent:
+				if e.Delta != "" {
+					contentType = consts.MessageType.Reasoning
+					currentContentBuilder.WriteString(e.Delta)
+					streamResponse.Data = ContentReasoning{Content: e.Delta}
+					streamResponse.Type = contentType
+				}
+			case responses.ResponseReasoningSummaryPartDoneEvent:
+				currentContentBuilder.WriteString("\n\n")
 				contentType = consts.MessageType.Reasoning
-				currentContentBuilder.WriteString(e.Delta)
-				streamResponse.Data = ContentReasoning{Content: e.Delta}
+				streamResponse.Data = ContentReasoning{Content: "\n\n"}
 				streamResponse.Type = contentType
+			case responses.ResponseOutputItemDoneEvent:
+				blockCount := len(contentList)
+				appendContent(&currentContentBuilder, contentType, &contentList)
+				currentContentBuilder.Reset()
+				if item, ok := outputItemParam(e.Item); ok {
+					inputItems = append(inputItems, item)
+				}
+				switch e.Item.Type {
+				case "reasoning":
+					reasoning := e.Item.AsReasoning()
+					if reasoning.EncryptedContent == "" {
+						continue
+					}
+					// The summary may be empty, the encrypted reasoning is
+					// kept either way
+					data := ContentReasoning{Id: reasoning.ID}
+					if len(contentList) > blockCount {
+						if summary, ok := contentList[blockCount].Data.(ContentReasoning); ok {
+							data.Content = summary.Content
+						}
+						contentList = contentList[:blockCount]
+					}
+					contentList = append(contentList, Content{
+						Type:      consts.MessageType.Reasoning,
+						Data:      data,
+						Signature: reasoning.EncryptedContent,
+					})
+					continue
+				case "message":
+					urlCitations := messageCitations(e.Item.AsMessage(), citationCount)
+					if len(urlCitations) == 0 {
+						continue
+					}
+					citationCount += len(urlCitations)
+					contentList = append(contentList, citationContent(urlCitations))
+					streamResponse.Type = consts.MessageType.Citation
+					streamResponse.Data = ContentCitation{Citations: urlCitations}
+				case "image_generation_call":
+					imageCall := e.Item.AsImageGenerationCall()
+					if imageCall.Result == "" {
+						continue
+					}
+					data, err := base64.StdEncoding.DecodeString(imageCall.Result)
+					if err != nil {
+						return err
+					}
+					image, err := saveImage(ctx, options.UserId, messageId, data, "")
+					if err != nil {
+						return err
+					}
+					contentList = append(contentList, Content{Type: consts.MessageType.Image, Data: image})
+					streamResponse.Type = consts.MessageType.Image
+					streamResponse.Data = image
+				case "function_call":
+					functionCall := e.Item.AsFunctionCall()
+					call := &ContentFunctionCall{
+						Id:        functionCall.CallID,
+						Name:      functionCall.Name,
+						Arguments: functionCall.Arguments,
+					}
+					calls = append(calls, call)
+					streamResponse.Type = consts.MessageType.FunctionCall
+					streamResponse.Data = call
+				default:
+					continue
+				}
+			case responses.ResponseTextDeltaEvent:
+				if e.Delta != "" {
+					contentType = consts.MessageType.Message
+					currentContentBuilder.WriteString(e.Delta)
+					streamResponse.Data = ContentMessage{Content: e.Delta}
+					streamResponse.Type = contentType
+				}
+			case responses.ResponseCompletedEvent:
+				messageMetaInfo.CachedTokenCount += int(e.Response.Usage.InputTokensDetails.CachedTokens)
+				messageMetaInfo.PromptTokenCount += int(e.Response.Usage.InputTokens)
+				messageMetaInfo.ReasoningTokenCount += int(e.Response.Usage.OutputTokensDetails.ReasoningTokens)
+				messageMetaInfo.ResponseTokenCount += int(e.Response.Usage.OutputTokens)
+				streamResponse.Type = consts.MessageType.MetaInfo
+				streamResponse.Data = messageMetaInfo
+			default:
+				continue
 			}
-		case responses.ResponseReasoningSummaryPartDoneEvent:
-			currentContentBuilder.WriteString("\n\n")
-			contentType = consts.MessageType.Reasoning
-			streamResponse.Data = ContentReasoning{"\n\n"}
-			streamResponse.Type = contentType
-		case responses.ResponseOutputItemDoneEvent:
-			appendContent(&currentContentBuilder, contentType, &contentList)
-			currentContentBuilder.Reset()
-			continue
-		case responses.ResponseTextDeltaEvent:
-			if e.Delta != "" {
-				contentType = consts.MessageType.Message
-				currentContentBuilder.WriteString(e.Delta)
-				streamResponse.Data = ContentMessage{Content: e.Delta}
-				streamResponse.Type = contentType
+			if streamResponse.Type == "" {
+				continue
 			}
-		case responses.ResponseCompletedEvent:
-			messageMetaInfo.CachedTokenCount = int(e.Response.Usage.InputTokensDetails.CachedTokens)
-			messageMetaInfo.PromptTokenCount = int(e.Response.Usage.InputTokens)
-			messageMetaInfo.ReasoningTokenCount = int(e.Response.Usage.OutputTokensDetails.ReasoningTokens)
-			messageMetaInfo.ResponseTokenCount = int(e.Response.Usage.OutputTokens)
-			streamResponse.Type = consts.MessageType.MetaInfo
-			streamResponse.Data = messageMetaInfo
-		default:
-			continue
+
+			err := sink.Send(streamResponse)
+			if err != nil {
+				if errors.Is(ctx.Err(), context.Canceled) {
+					saveMessage(context.WithoutCancel(ctx))
+					return nil
+				}
+				return err
+			}
+			checkpoint()
 		}
 
-		err := StreamToClient(response, streamResponse)
-		if err != nil {
+		if err := stream.Err(); err != nil {
 			if errors.Is(ctx.Err(), context.Canceled) {
 				saveMessage(context.WithoutCancel(ctx))
 				return nil
 			}
 			return err
 		}
-	}
+		if len(calls) == 0 {
+			break
+		}
 
-	if err := stream.Err(); err != nil {
-		if errors.Is(ctx.Err(), context.Canceled) {
-			saveMessage(context.WithoutCancel(ctx))
-			return nil
+		for _, call := range calls {
+			var callCitations []Citation
+			var callFiles []ToolFile
+			if step < maxSteps {
+				callCitations, callFiles = executeTool(ctx, call, citationCount)
+			} else {
+				refuseTool(call)
+			}
+			contentList = append(contentList, Content{Type: consts.MessageType.FunctionCall, Data: call})
+			if len(callCitations) > 0 {
+				citationCount += len(callCitations)
+				contentList = append(contentList, citationContent(callCitations))
+				err := sink.Send(StreamResponse{
+					MessageId: messageId,
+					Type:      consts.MessageType.Citation,
+					Data:      ContentCitation{Citations: callCitations},
+				})
+				if err != nil {
+					if errors.Is(ctx.Err(), context.Canceled) {
+						saveMessage(context.WithoutCancel(ctx))
+						return nil
+					}
+					return err
+				}
+			}
+			for _, file := range saveToolFiles(ctx, call, callFiles, options.UserId, messageId) {
+				contentList = append(contentList, Content{Type: consts.MessageType.Attachment, Data: file})
+				err := sink.Send(StreamResponse{
+					MessageId: messageId,
+					Type:      consts.MessageType.Attachment,
+					Data:      file,
+				})
+				if err != nil {
+					if errors.Is(ctx.Err(), context.Canceled) {
+						saveMessage(context.WithoutCancel(ctx))
+						return nil
+					}
+					return err
+				}
+			}
+			inputItems = append(inputItems, responses.ResponseInputItemParamOfFunctionCallOutput(call.Id, call.Result))
+			err := sink.Send(StreamResponse{
+				MessageId: messageId,
+				Type:      consts.MessageType.FunctionCall,
+				Data:      call,
+			})
+			if err != nil {
+				if errors.Is(ctx.Err(), context.Canceled) {
+					saveMessage(context.WithoutCancel(ctx))
+					return nil
+				}
+				return err
+			}
+		}
+		// Refused calls get one more request without tools, so the model
+		// answers with what it already has
+		if step > maxSteps {
+			break
+		}
+		if step == maxSteps {
+			params.ToolChoice = responses.ResponseNewParamsToolChoiceUnion{
+				OfToolChoiceMode: openai.Opt(responses.ToolChoiceOptionsNone),
+			}
 		}
-		return err
 	}
 
 	saveMessage(ctx)
-	response.Writef("data: [DONE]\n\n")
-	response.Flush()
+	sink.Done(messageId)
 	return nil
 }
 
+// outputItemParam converts an output item of a response into an input item,
+// so the next step of a tool call loop sees the full previous turn.
+func outputItemParam(item responses.ResponseOutputItemUnion) (responses.ResponseInputItemUnionParam, bool) {
+	switch item.Type {
+	case "message":
+		param := item.AsMessage().ToParam()
+		return responses.ResponseInputItemUnionParam{OfOutputMessage: &param}, true
+	case "reasoning":
+		param := item.AsReasoning().ToParam()
+		return responses.ResponseInputItemUnionParam{OfReasoning: &param}, true
+	case "function_call":
+		param := item.AsFunctionCall().ToParam()
+		return responses.ResponseInputItemUnionParam{OfFunctionCall: &param}, true
+	case "image_generation_call":
+		imageCall := item.AsImageGenerationCall()
+		return responses.ResponseInputItemUnionParam{OfImageGenerationCall: &responses.ResponseInputItemImageGenerationCallParam{
+			ID:     imageCall.ID,
+			Result: openai.String(imageCall.Result),
+			Status: imageCall.Status,
+		}}, true
+	}
+	return responses.ResponseInputItemUnionParam{}, false
+}
+
+// followedByOutput reports whether a reasoning item is followed by the output
+// it led to. The API rejects reasoning items replayed without it, as left
+// behind when an answer was stopped.
+func followedByOutput(contents []Content) bool {
+	for _, content := range contents {
+		switch content.Type {
+		case consts.MessageType.Message, consts.MessageType.FunctionCall:
+			return true
+		case consts.MessageType.Reasoning:
+			return false
+		}
+	}
+	return false
+}
+
+// messageCitations collects the url_citation annotations the web search tool
+// adds to a message. Annotations of the same url are merged into one citation
+// with several spans.
+func messageCitations(message responses.ResponseOutputMessage, citationCount int) []Citation {
+	var citations []Citation
+	byUrl := make(map[string]int)
+	offset := 0
+	for _, content := range message.Content {
+		if content.Type != "output_text" {
+			continue
+		}
+		text := []rune(content.Text)
+		for _, annotation := range content.Annotations {
+			if annotation.Type != "url_citation" {
+				continue
+			}
+			start := int(max(0, min(annotation.StartIndex, int64(len(text)))))
+			end := int(max(int64(start), min(annotation.EndIndex, int64(len(text)))))
+			span := CitationSpan{Start: offset + start, End: offset + end, Text: string(text[start:end])}
+			i, ok := byUrl[annotation.URL]
+			if !ok {
+				i = len(citations)
+				byUrl[annotation.URL] = i
+				citations = append(citations, Citation{
+					Index:  citationCount + i + 1,
+					Source: consts.CitationSource.Web,
+					Title:  annotation.Title,
+					Url:    annotation.URL,
+				})
+			}
+			citations[i].Spans = append(citations[i].Spans, span)
+		}
+		offset += len(text)
+	}
+	return citations
+}
+
+// buildInputItems converts a stored message into Responses API input items.
+// User messages carry their attachments as input_image and input_file parts,
+// or as extracted text when the model cannot read the file natively. Images
+// generated by the model cannot be sent as assistant content, so they follow
+// the answer as user input_image parts to allow iterative edits. Answers of
+// the same model also replay their encrypted reasoning and function calls.
+// Only files of the user are loaded.
+func (c *OpenAIClient) buildInputItems(ctx context.Context, providerInfo *logic.SimpleProviderInfo, modelConfig *logic.ModelConfig, userId string, msg *entity.Message) ([]responses.ResponseInputItemUnionParam, error) {
+	contents, err := decodeContents(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []responses.ResponseInputItemUnionParam
+	if msg.Role == consts.MessageRole.Assistant {
+		replay := replayMetaInfo(msg, providerInfo, modelConfig) != nil
+		var images responses.ResponseInputMessageContentListParam
+		for i, content := range contents {
+			switch content.Type {
+			case consts.MessageType.Reasoning:
+				if !replay || content.Signature == "" || !followedByOutput(contents[i+1:]) {
+					continue
+				}
+				var data ContentReasoning
+				if err := mapstructure.Decode(content.Data, &data); err != nil {
+					return nil, err
+				}
+				items = append(items, responses.ResponseInputItemUnionParam{OfReasoning: &responses.ResponseReasoningItemParam{
+					ID:               data.Id,
+					Summary:          []responses.ResponseReasoningItemSummaryParam{},
+					EncryptedContent: openai.String(content.Signature),
+				}})
+			case consts.MessageType.FunctionCall:
+				if !replay {
+					continue
+				}
+				var data ContentFunctionCall
+				if err := mapstructure.Decode(content.Data, &data); err != nil {
+					return nil, err
+				}
+				items = append(items,
+					responses.ResponseInputItemParamOfFunctionCall(data.Arguments, data.Id, data.Name),
+					responses.ResponseInputItemParamOfFunctionCallOutput(data.Id, data.Result),
+				)
+			case consts.MessageType.Message:
+				var data ContentMessage
+				if err := mapstructure.Decode(content.Data, &data); err != nil {
+					return nil, err
+				}
+				items = append(items, responses.ResponseInputItemParamOfMessage(data.Content, responses.EasyInputMessageRoleAssistant))
+			case consts.MessageType.Image:
+				var data ContentImage
+				if err := mapstructure.Decode(content.Data, &data); err != nil {
+					return nil, err
+				}
+				file, fileData, err := attachment.Load(ctx, userId, data.Id)
+				if errors.Is(err, attachment.ErrNotFound) {
+					images = append(images, responses.ResponseInputContentUnionParam{
+						OfInputText: &responses.ResponseInputTextParam{Text: missingFile(data.FileName)},
+					})
+					continue
+				}
+				if err != nil {
+					return nil, err
+				}
+				images = append(images, responses.ResponseInputContentUnionParam{
+					OfInputImage: &responses.ResponseInputImageParam{
+						ImageURL: openai.String(fmt.Sprintf("data:%s;base64,%s", file.MimeType, base64.StdEncoding.EncodeToString(fileData))),
+						Detail:   responses.ResponseInputImageDetailAuto,
+					},
+				})
+			}
+		}
+		if len(images) > 0 {
+			images = append(responses.ResponseInputMessageContentListParam{{
+				OfInputText: &responses.ResponseInputTextParam{Text: "Images generated in the previous answer:"},
+			}}, images...)
+			items = append(items, responses.ResponseInputItemParamOfMessage(images, responses.EasyInputMessageRoleUser))
+		}
+		return items, nil
+	}
+
+	var parts responses.ResponseInputMessageContentListParam
+	for _, content := range contents {
+		switch content.Type {
+		case consts.MessageType.Message:
+			var data ContentMessage
+			if err := mapstructure.Decode(content.Data, &data); err != nil {
+				return nil, err
+			}
+			parts = append(parts, responses.ResponseInputContentUnionParam{
+				OfInputText: &responses.ResponseInputTextParam{Text: data.Content},
+			})
+		case consts.MessageType.Attachment:
+			var data ContentAttachment
+			if err := mapstructure.Decode(content.Data, &data); err != nil {
+				return nil, err
+			}
+			file, fileData, err := attachment.Load(ctx, userId, data.Id)
+			if errors.Is(err, attachment.ErrNotFound) {
+				parts = append(parts, responses.ResponseInputContentUnionParam{
+					OfInputText: &responses.ResponseInputTextParam{Text: missingFile(data.FileName)},
+				})
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if !attachment.SendNatively(modelConfig, file) {
+				texts, err := documentContext(ctx, modelConfig, file, fileData)
+				if err != nil {
+					return nil, err
+				}
+				for _, text := range texts {
+					parts = append(parts, responses.ResponseInputContentUnionParam{
+						OfInputText: &responses.ResponseInputTextParam{Text: text},
+					})
+				}
+				continue
+			}
+			dataUrl := fmt.Sprintf("data:%s;base64,%s", file.MimeType, base64.StdEncoding.EncodeToString(fileData))
+			if attachment.Kind(file.MimeType) == attachment.KindImage {
+				parts = append(parts, responses.ResponseInputContentUnionParam{
+					OfInputImage: &responses.ResponseInputImageParam{
+						ImageURL: openai.String(dataUrl),
+						Detail:   responses.ResponseInputImageDetailAuto,
+					},
+				})
+			} else {
+				parts = append(parts, responses.ResponseInputContentUnionParam{
+					OfInputFile: &responses.ResponseInputFileParam{
+						FileData: openai.String(dataUrl),
+						Filename: openai.String(file.FileName),
+					},
+				})
+			}
+		}
+	}
+	if len(parts) > 0 {
+		items = append(items, responses.ResponseInputItemParamOfMessage(parts, responses.EasyInputMessageRoleUser))
+	}
+	return items, nil
+}
+
 func (c *OpenAIClient) GenerateTitle(ctx context.Context, providerInfo *logic.SimpleProviderInfo, modelConfig *logic.ModelConfig, systemInstruction string, content string) (*TitleGenerationResponse, error) {
 	client := c.getClient(ctx, providerInfo)
 
@@ -243,3 +639,30 @@ func (c *OpenAIClient) GenerateTitle(ctx context.Context, providerInfo *logic.Si
 	}
 	return &titleGenerationResponse, nil
 }
+
+func (c *OpenAIClient) Embed(ctx context.Context, providerInfo *logic.SimpleProviderInfo, model string, inputs []string) ([][]float32, error) {
+	client := c.getClient(ctx, providerInfo)
+	resp, err := client.Embeddings.New(ctx, openai.EmbeddingNewParams{
+		Model: openai.EmbeddingModel(model),
+		Input: openai.EmbeddingNewParamsInputUnion{
+			OfArrayOfStrings: inputs,
+		},
+		EncodingFormat: openai.EmbeddingNewParamsEncodingFormatFloat,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings := make([][]float32, len(inputs))
+	for _, data := range resp.Data {
+		if data.Index < 0 || int(data.Index) >= len(inputs) {
+			return nil, fmt.Errorf("embedding index %d out of range", data.Index)
+		}
+		vector := make([]float32, len(data.Embedding))
+		for i, value := range data.Embedding {
+			vector[i] = float32(value)
+		}
+		embeddings[data.Index] = vector
+	}
+	return embeddings, nil
+}
@@ -0,0 +1,172 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"flai/internal/consts"
+	"flai/internal/logic"
+	"flai/internal/logic/keypool"
+	"flai/internal/model"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/util/gconv"
+	openai "github.com/openai/openai-go/v3"
+	"google.golang.org/genai"
+)
+
+const (
+	defaultRetryMaxAttempts  = 3
+	defaultRetryInitialDelay = time.Second
+	defaultRetryMaxDelay     = 10 * time.Second
+)
+
+// RetryConfig controls how often a request to a model is repeated after a
+// transient error. MaxAttempts includes the first one.
+type RetryConfig struct {
+	MaxAttempts  int
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+}
+
+func GetRetryConfig(ctx context.Context) *RetryConfig {
+	return &RetryConfig{
+		MaxAttempts:  max(1, g.Cfg().MustGet(ctx, "llm.retry.maxAttempts", defaultRetryMaxAttempts).Int()),
+		InitialDelay: g.Cfg().MustGet(ctx, "llm.retry.initialDelay", defaultRetryInitialDelay).Duration(),
+		MaxDelay:     g.Cfg().MustGet(ctx, "llm.retry.maxDelay", defaultRetryMaxDelay).Duration(),
+	}
+}
+
+// delay returns the exponential backoff after the given attempt.
+func (c *RetryConfig) delay(attempt int) time.Duration {
+	delay := c.InitialDelay
+	for i := 1; i < attempt && delay < c.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, c.MaxDelay)
+}
+
+// sleep waits for the delay and reports false when the request was canceled
+// first.
+func sleep(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// isTransient reports whether the error is worth retrying: rate limits,
+// server errors and broken connections.
+func isTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if status := statusCode(err); status != 0 {
+		return transientStatus(status)
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET)
+}
+
+// statusCode returns the HTTP status of a provider error, or 0.
+func statusCode(err error) int {
+	var openaiErr *openai.Error
+	if errors.As(err, &openaiErr) {
+		return openaiErr.StatusCode
+	}
+	var geminiErr genai.APIError
+	if errors.As(err, &geminiErr) {
+		return geminiErr.Code
+	}
+	return 0
+}
+
+func transientStatus(status int) bool {
+	return status == http.StatusRequestTimeout || status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// withKey returns the provider set up with a key of its pool, and the key to
+// report the outcome of the request on. Providers without a pool use their
+// own key.
+func withKey(providerInfo *logic.SimpleProviderInfo) (*logic.SimpleProviderInfo, *keypool.Key) {
+	key := keypool.Acquire(providerInfo.Id, providerInfo.KeySelection)
+	if key == nil {
+		return providerInfo, nil
+	}
+	keyed := *providerInfo
+	keyed.ApiKey = key.ApiKey
+	return &keyed, key
+}
+
+// reportKey records the outcome of a request on the key. Canceled requests
+// say nothing about the key.
+func reportKey(ctx context.Context, key *keypool.Key, err error) {
+	if errors.Is(err, context.Canceled) {
+		err = nil
+	}
+	keypool.Report(ctx, key, statusCode(err), err)
+}
+
+// FallbackModel is one entry of a fallback chain.
+type FallbackModel struct {
+	ProviderId string `json:"provider_id"`
+	ModelName  string `json:"model_name"`
+}
+
+type chatCandidate struct {
+	providerInfo *logic.SimpleProviderInfo
+	modelConfig  *logic.ModelConfig
+}
+
+// chatCandidates returns the requested model followed by the models after it
+// in the first fallback chain containing it. The chains are configured by
+// admins in the model_fallback system config as
+// {"chains": [[{"provider_id": "...", "model_name": "..."}, ...]]}. Fallback
+// models that are inactive or do not support the settings are skipped.
+func chatCandidates(providerInfo *logic.SimpleProviderInfo, modelConfig *logic.ModelConfig, settings *model.ChatSettings) []chatCandidate {
+	candidates := []chatCandidate{{providerInfo: providerInfo, modelConfig: modelConfig}}
+	config, ok := logic.SystemConfigMap[consts.SystemConfig.ModelFallback]
+	if !ok {
+		return candidates
+	}
+	var chains [][]FallbackModel
+	if err := gconv.Scan(config["chains"], &chains); err != nil {
+		return candidates
+	}
+	for _, chain := range chains {
+		position := -1
+		for i, entry := range chain {
+			if entry.ProviderId == providerInfo.Id && entry.ModelName == modelConfig.ID {
+				position = i
+				break
+			}
+		}
+		if position < 0 {
+			continue
+		}
+		for _, entry := range chain[position+1:] {
+			fallbackProvider := logic.ProviderMap[entry.ProviderId]
+			if fallbackProvider == nil {
+				continue
+			}
+			fallbackModel := fallbackProvider.ModelIdMap[entry.ModelName]
+			if fallbackModel == nil || CheckModelSettings(fallbackProvider, fallbackModel, settings) != nil {
+				continue
+			}
+			candidates = append(candidates, chatCandidate{providerInfo: fallbackProvider, modelConfig: fallbackModel})
+		}
+		break
+	}
+	return candidates
+}
@@ -0,0 +1,131 @@
+package llm
+
+import (
+	"flai/internal/consts"
+	"flai/internal/logic"
+	"flai/internal/model"
+	"slices"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	maxSystemPromptLength = 32000
+	maxStopSequences      = 5
+)
+
+var reasoningEfforts = []string{
+	consts.ReasoningEffort.Minimal,
+	consts.ReasoningEffort.Low,
+	consts.ReasoningEffort.Medium,
+	consts.ReasoningEffort.High,
+}
+
+// Token budgets the effort levels translate to for providers that take a
+// budget, and the upper bounds used to translate a budget back to a level
+var thinkingBudgets = map[string]int{
+	consts.ReasoningEffort.Minimal: 512,
+	consts.ReasoningEffort.Low:     2048,
+	consts.ReasoningEffort.Medium:  8192,
+	consts.ReasoningEffort.High:    24576,
+}
+
+// CheckSettings validates the ranges of the settings independent of a model.
+func CheckSettings(settings *model.ChatSettings) error {
+	if settings == nil {
+		return nil
+	}
+	if len([]rune(settings.SystemPrompt)) > maxSystemPromptLength {
+		return gerror.Newf("system prompt exceeds %d characters", maxSystemPromptLength)
+	}
+	if t := settings.Temperature; t != nil && (*t < 0 || *t > 2) {
+		return gerror.New("temperature must be between 0 and 2")
+	}
+	if p := settings.TopP; p != nil && (*p <= 0 || *p > 1) {
+		return gerror.New("top_p must be greater than 0 and at most 1")
+	}
+	if n := settings.MaxOutputTokens; n != nil && *n < 1 {
+		return gerror.New("max_output_tokens must be positive")
+	}
+	if len(settings.StopSequences) > maxStopSequences {
+		return gerror.Newf("at most %d stop sequences are allowed", maxStopSequences)
+	}
+	for _, stop := range settings.StopSequences {
+		if strings.TrimSpace(stop) == "" {
+			return gerror.New("stop sequences cannot be empty")
+		}
+	}
+	if effort := settings.ReasoningEffort; effort != "" && !slices.Contains(reasoningEfforts, effort) {
+		return gerror.Newf("reasoning_effort must be one of %s", strings.Join(reasoningEfforts, ", "))
+	}
+	if n := settings.ThinkingBudget; n != nil && *n < 0 {
+		return gerror.New("thinking_budget cannot be negative")
+	}
+	if settings.ReasoningEffort != "" && settings.ThinkingBudget != nil {
+		return gerror.New("only one of reasoning_effort and thinking_budget can be set")
+	}
+	return nil
+}
+
+// CheckModelSettings validates the settings against what the model and its
+// provider support.
+func CheckModelSettings(providerInfo *logic.SimpleProviderInfo, modelConfig *logic.ModelConfig, settings *model.ChatSettings) error {
+	if err := CheckSettings(settings); err != nil {
+		return err
+	}
+	if settings == nil {
+		return nil
+	}
+	if (settings.Temperature != nil || settings.TopP != nil) && !modelConfig.Temperature {
+		return gerror.Newf("model %s does not support temperature or top_p", modelConfig.Name)
+	}
+	if n := settings.MaxOutputTokens; n != nil && modelConfig.Limit.Output > 0 && int64(*n) > modelConfig.Limit.Output {
+		return gerror.Newf("max_output_tokens exceeds the output limit of %d tokens of model %s", modelConfig.Limit.Output, modelConfig.Name)
+	}
+	if (settings.ReasoningEffort != "" || settings.ThinkingBudget != nil) && !modelConfig.Reasoning {
+		return gerror.Newf("model %s does not support reasoning", modelConfig.Name)
+	}
+	if n := settings.ThinkingBudget; n != nil && modelConfig.Limit.Output > 0 && int64(*n) > modelConfig.Limit.Output {
+		return gerror.Newf("thinking_budget exceeds the output limit of %d tokens of model %s", modelConfig.Limit.Output, modelConfig.Name)
+	}
+	// The Responses API has no stop parameter
+	if len(settings.StopSequences) > 0 && providerInfo.ProviderType == consts.ProviderType.OpenAI {
+		return gerror.New("stop sequences are not supported by OpenAI models")
+	}
+	return nil
+}
+
+// reasoningEffort returns the effort level for the settings, translating a
+// thinking budget to the smallest level that covers it.
+func reasoningEffort(settings *model.ChatSettings) string {
+	if settings == nil {
+		return ""
+	}
+	if settings.ReasoningEffort != "" || settings.ThinkingBudget == nil {
+		return settings.ReasoningEffort
+	}
+	for _, effort := range reasoningEfforts {
+		if *settings.ThinkingBudget <= thinkingBudgets[effort] {
+			return effort
+		}
+	}
+	return consts.ReasoningEffort.High
+}
+
+// thinkingBudget returns the token budget for the settings, translating an
+// effort level to its budget.
+func thinkingBudget(settings *model.ChatSettings) *int32 {
+	if settings == nil {
+		return nil
+	}
+	if settings.ThinkingBudget != nil {
+		budget := int32(*settings.ThinkingBudget)
+		return &budget
+	}
+	if budget, ok := thinkingBudgets[settings.ReasoningEffort]; ok {
+		budget := int32(budget)
+		return &budget
+	}
+	return nil
+}
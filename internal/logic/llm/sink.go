@@ -0,0 +1,67 @@
+package llm
+
+import (
+	"flai/internal/consts"
+	"sync"
+)
+
+// Sink receives the events of an answer while it is generated.
+type Sink interface {
+	// Send streams an event of the answer.
+	Send(event StreamResponse) error
+	// Done marks the end of the answer.
+	Done(messageId string)
+	// Size is the amount of data sent so far, an attempt that changed it
+	// cannot be repeated.
+	Size() int64
+}
+
+// multiplexer shares one sink between answers streamed concurrently. Their
+// events are told apart by message id.
+type multiplexer struct {
+	mu     sync.Mutex
+	parent Sink
+}
+
+// sink returns the sink of one of the answers.
+func (m *multiplexer) sink() Sink {
+	return &multiplexSink{multiplexer: m}
+}
+
+// close ends the response once all answers are done.
+func (m *multiplexer) close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.parent.Done("")
+}
+
+// multiplexSink streams one answer to the shared sink. Its size only counts
+// the events of that answer.
+type multiplexSink struct {
+	*multiplexer
+	size int64
+}
+
+func (s *multiplexSink) Send(event StreamResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	before := s.parent.Size()
+	err := s.parent.Send(event)
+	s.size += s.parent.Size() - before
+	return err
+}
+
+// Done sends a done event instead of [DONE], the other answers may still be
+// streaming.
+func (s *multiplexSink) Done(messageId string) {
+	_ = s.Send(StreamResponse{
+		MessageId: messageId,
+		Type:      consts.MessageType.Done,
+	})
+}
+
+func (s *multiplexSink) Size() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.size
+}
@@ -8,17 +8,87 @@ type StreamResponse struct {
 	Data      any    `json:"data"`
 }
 
+// ContentReasoning is the reasoning summary of the model. Id is the id of the
+// OpenAI reasoning item it belongs to.
 type ContentReasoning struct {
 	Content string `json:"content"`
+	Id      string `json:"id,omitempty"`
 }
 
 type ContentMessage struct {
 	Content string `json:"content"`
 }
 
+type ContentAttachment struct {
+	Id       string `json:"id"`
+	FileName string `json:"file_name"`
+	MimeType string `json:"mime_type"`
+	Size     int64  `json:"size"`
+}
+
+// ContentImage is an image generated by the model, stored as an attachment of
+// the assistant message.
+type ContentImage struct {
+	Id       string `json:"id"`
+	FileName string `json:"file_name"`
+	MimeType string `json:"mime_type"`
+	Size     int64  `json:"size"`
+}
+
+// ContentFunctionCall is a tool call made by the model together with its
+// result. Id matches the call across the streamed events.
+type ContentFunctionCall struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+	Result    string `json:"result"`
+	IsError   bool   `json:"is_error,omitempty"`
+}
+
+// Citation is a source an answer is grounded on. Index is the number the
+// model uses to refer to the source in its answer. Spans are only known for
+// sources cited by the provider's own search.
+type Citation struct {
+	Index           int            `json:"index"`
+	Source          string         `json:"source"`
+	Title           string         `json:"title"`
+	Url             string         `json:"url,omitempty"`
+	Snippet         string         `json:"snippet,omitempty"`
+	KnowledgeBaseId string         `json:"knowledge_base_id,omitempty"`
+	DocumentId      string         `json:"document_id,omitempty"`
+	ChunkId         string         `json:"chunk_id,omitempty"`
+	Score           float64        `json:"score,omitempty"`
+	Spans           []CitationSpan `json:"spans,omitempty"`
+}
+
+// CitationSpan is a part of the answer supported by a citation. Start and End
+// are character offsets into the message block preceding the citation block,
+// End is exclusive.
+type CitationSpan struct {
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Text  string `json:"text,omitempty"`
+}
+
+// ContentCitation holds the sources found at one point of the answer. The
+// citation block follows the content it belongs to.
+type ContentCitation struct {
+	Citations []Citation `json:"citations"`
+}
+
+// Content is one stored block of a message. Signature is the opaque state
+// the provider attached to the block, a Gemini thought signature or OpenAI
+// encrypted reasoning, and is sent back when the same model continues.
 type Content struct {
-	Type string `json:"type"`
-	Data any    `json:"data"`
+	Type      string `json:"type"`
+	Data      any    `json:"data"`
+	Signature string `json:"signature,omitempty"`
+}
+
+// ContentError is streamed when generating an answer failed after the
+// response was already started.
+type ContentError struct {
+	Message string `json:"message"`
 }
 
 type TitleGenerationResponse struct {
@@ -27,13 +97,23 @@ type TitleGenerationResponse struct {
 }
 
 type MessageMetaInfo struct {
-	ProviderName        string                   `json:"provider_name"`
-	ModelName           string                   `json:"model_name"`
-	PromptTokenCount    int                      `json:"prompt_token_count"`
-	ReasoningTokenCount int                      `json:"reasoning_token_count"`
-	ResponseTokenCount  int                      `json:"response_token_count"`
-	ToolUseTokenCount   int                      `json:"tool_use_token_count"`
-	CachedTokenCount    int                      `json:"cached_token_count"`
-	ThoughtSignature    string                   `json:"thought_signature"`
+	ProviderId   string `json:"provider_id,omitempty"`
+	ProviderName string `json:"provider_name"`
+	ModelId      string `json:"model_id,omitempty"`
+	ModelName    string `json:"model_name"`
+	// FallbackFrom is the model that was requested when the answer came from
+	// a fallback model.
+	FallbackFrom        string `json:"fallback_from,omitempty"`
+	Alias               string `json:"alias,omitempty"`
+	ReasoningEffort     string `json:"reasoning_effort,omitempty"`
+	ThinkingBudget      *int   `json:"thinking_budget,omitempty"`
+	PromptTokenCount    int    `json:"prompt_token_count"`
+	ReasoningTokenCount int    `json:"reasoning_token_count"`
+	ResponseTokenCount  int    `json:"response_token_count"`
+	ToolUseTokenCount   int    `json:"tool_use_token_count"`
+	CachedTokenCount    int    `json:"cached_token_count"`
+	ThoughtSignature    string `json:"thought_signature"`
+	// GoogleGroundingData is only set on messages saved before grounding was
+	// stored as citation blocks.
 	GoogleGroundingData *genai.GroundingMetadata `json:"google_grounding_data,omitempty"`
 }
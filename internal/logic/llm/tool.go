@@ -0,0 +1,220 @@
+package llm
+
+import (
+	"context"
+	"flai/internal/consts"
+	"flai/internal/logic"
+	"flai/internal/logic/attachment"
+	"flai/internal/model/entity"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+const (
+	defaultMaxToolIterations = 8
+	defaultToolTimeout       = 60 * time.Second
+)
+
+// Tool is a function the model can call during a chat. Parameters is the
+// JSON schema of the arguments object and Execute receives the arguments as
+// JSON text.
+type Tool struct {
+	Name        string
+	Description string
+	Parameters  map[string]any
+	Timeout     time.Duration
+	Execute     func(ctx context.Context, arguments string) (*ToolOutput, error)
+}
+
+// ToolOutput is the result of a tool call. Citations are numbered by the chat,
+// listed after the content for the model and added to the message sources.
+// Files are attached to the assistant message.
+type ToolOutput struct {
+	Content   string
+	Citations []Citation
+	Files     []ToolFile
+}
+
+// ToolFile is a file produced by a tool.
+type ToolFile struct {
+	Name     string
+	MimeType string
+	Data     []byte
+}
+
+var (
+	toolMu       sync.RWMutex
+	toolRegistry = make(map[string]*Tool)
+)
+
+// RegisterTool makes a tool available to chats, replacing any tool with the
+// same name.
+func RegisterTool(tool *Tool) {
+	toolMu.Lock()
+	defer toolMu.Unlock()
+	toolRegistry[tool.Name] = tool
+}
+
+// UnregisterTool removes a tool from the registry.
+func UnregisterTool(name string) {
+	toolMu.Lock()
+	defer toolMu.Unlock()
+	delete(toolRegistry, name)
+}
+
+// GetTool returns the registered tool with the name.
+func GetTool(name string) (*Tool, bool) {
+	toolMu.RLock()
+	defer toolMu.RUnlock()
+	tool, ok := toolRegistry[name]
+	return tool, ok
+}
+
+// ListTools returns the registered tools sorted by name.
+func ListTools() []*Tool {
+	toolMu.RLock()
+	defer toolMu.RUnlock()
+	tools := make([]*Tool, 0, len(toolRegistry))
+	for _, tool := range toolRegistry {
+		tools = append(tools, tool)
+	}
+	slices.SortFunc(tools, func(a, b *Tool) int {
+		if a.Name < b.Name {
+			return -1
+		}
+		if a.Name > b.Name {
+			return 1
+		}
+		return 0
+	})
+	return tools
+}
+
+// CheckTools makes sure every requested tool is known.
+func CheckTools(names []string) error {
+	for _, name := range names {
+		if name == consts.InternalTools.InternalWebSearch || name == consts.InternalTools.InternalImageGeneration {
+			continue
+		}
+		if _, ok := GetTool(name); !ok {
+			return gerror.Newf("unknown tool: %s", name)
+		}
+	}
+	return nil
+}
+
+// functionTools resolves the requested tool names to registered function
+// tools. Models without tool calling support get none.
+func functionTools(modelConfig *logic.ModelConfig, names []string) []*Tool {
+	if !modelConfig.ToolCall {
+		return nil
+	}
+	var tools []*Tool
+	for _, name := range names {
+		if tool, ok := GetTool(name); ok {
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
+// maxToolIterations bounds how many rounds of tool calls a single answer may
+// take before the remaining calls are refused.
+func maxToolIterations(ctx context.Context) int {
+	return g.Cfg().MustGet(ctx, "llm.maxToolIterations", defaultMaxToolIterations).Int()
+}
+
+// executeTool runs the tool requested by the model and records the result on
+// the call. Failures are reported to the model instead of ending the chat.
+// Citations of the result are numbered after the ones already given.
+func executeTool(ctx context.Context, call *ContentFunctionCall, citationCount int) ([]Citation, []ToolFile) {
+	tool, ok := GetTool(call.Name)
+	if !ok {
+		call.Result = fmt.Sprintf("Tool %s is not available.", call.Name)
+		call.IsError = true
+		return nil, nil
+	}
+	timeout := tool.Timeout
+	if timeout <= 0 {
+		timeout = defaultToolTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	output, err := func() (output *ToolOutput, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = gerror.Newf("tool panicked: %v", r)
+			}
+		}()
+		return tool.Execute(ctx, call.Arguments)
+	}()
+	if err != nil {
+		g.Log().Warningf(ctx, "Tool %s failed: %v", call.Name, err)
+		call.Result = err.Error()
+		call.IsError = true
+		return nil, nil
+	}
+	if output == nil {
+		return nil, nil
+	}
+
+	citations := make([]Citation, 0, len(output.Citations))
+	sb := strings.Builder{}
+	sb.WriteString(output.Content)
+	for i, citation := range output.Citations {
+		citation.Index = citationCount + i + 1
+		citations = append(citations, citation)
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(fmt.Sprintf("<source index=\"%d\" title=\"%s\" url=\"%s\">\n%s\n</source>", citation.Index, citation.Title, citation.Url, citation.Snippet))
+	}
+	call.Result = sb.String()
+	return citations, output.Files
+}
+
+// saveToolFiles stores the files of a tool call as attachments of the
+// assistant message. Files that cannot be stored are reported to the model.
+func saveToolFiles(ctx context.Context, call *ContentFunctionCall, files []ToolFile, userId string, messageId string) []ContentAttachment {
+	var saved []*entity.Attachment
+	for _, file := range files {
+		mimeType := file.MimeType
+		if mimeType == "" {
+			mimeType = attachment.DetectMimeType(file.Name, file.Data)
+		}
+		record, err := attachment.SaveBytes(ctx, userId, file.Name, mimeType, file.Data)
+		if err != nil {
+			g.Log().Warningf(ctx, "Failed to save file %s of tool %s: %v", file.Name, call.Name, err)
+			call.Result += fmt.Sprintf("\nFile %s could not be attached: %v", file.Name, err)
+			continue
+		}
+		saved = append(saved, record)
+	}
+	if err := attachment.Bind(ctx, saved, messageId); err != nil {
+		g.Log().Warningf(ctx, "Failed to bind files of tool %s: %v", call.Name, err)
+	}
+	contents := make([]ContentAttachment, 0, len(saved))
+	for _, record := range saved {
+		contents = append(contents, ContentAttachment{
+			Id:       record.Id,
+			FileName: record.FileName,
+			MimeType: record.MimeType,
+			Size:     record.Size,
+		})
+	}
+	return contents
+}
+
+// refuseTool records a call that is not executed because the model used up
+// its tool iterations.
+func refuseTool(call *ContentFunctionCall) {
+	call.Result = "Tool call limit reached. Answer with the information you already have."
+	call.IsError = true
+}
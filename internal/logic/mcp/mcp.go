@@ -0,0 +1,425 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"flai/internal/consts"
+	"flai/internal/dao"
+	"flai/internal/logic/llm"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	sdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	connectTimeout    = 30 * time.Second
+	reconnectMinDelay = 5 * time.Second
+	reconnectMaxDelay = 5 * time.Minute
+)
+
+// Config is the decoded connection settings of a server.
+type Config struct {
+	Args         []string          `json:"args"`
+	Env          map[string]string `json:"env"`
+	Headers      map[string]string `json:"headers"`
+	AllowedTools []string          `json:"allowed_tools"`
+}
+
+// Status describes the connection of a registered server.
+type Status struct {
+	Connected bool     `json:"connected"`
+	Tools     []string `json:"tools"`
+	Error     string   `json:"error,omitempty"`
+}
+
+type connection struct {
+	mu      sync.Mutex
+	server  *entity.McpServer
+	config  *Config
+	session *sdk.ClientSession
+	tools   []string
+	err     error
+	// stopped is closed when the server is disconnected.
+	stopped chan struct{}
+}
+
+var (
+	mu          sync.Mutex
+	connections = make(map[string]*connection)
+)
+
+// ParseConfig decodes the json columns of a server.
+func ParseConfig(server *entity.McpServer) (*Config, error) {
+	config := &Config{}
+	columns := []struct {
+		value  string
+		target any
+	}{
+		{server.Args, &config.Args},
+		{server.Env, &config.Env},
+		{server.Headers, &config.Headers},
+		{server.AllowedTools, &config.AllowedTools},
+	}
+	for _, column := range columns {
+		if column.value == "" {
+			continue
+		}
+		if err := json.Unmarshal([]byte(column.value), column.target); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
+
+// Start connects to every active server and registers their tools. Servers
+// that cannot be reached are logged and retried in the background.
+func Start(ctx context.Context) {
+	var servers []*entity.McpServer
+	err := dao.McpServer.Ctx(ctx).Where(do.McpServer{IsActive: 1}).Scan(&servers)
+	if err != nil {
+		g.Log().Errorf(ctx, "Failed to load MCP servers: %v", err)
+		return
+	}
+	for _, server := range servers {
+		if err = start(ctx, server); err != nil {
+			g.Log().Warningf(ctx, "Failed to connect MCP server %s: %v", server.Name, err)
+		}
+	}
+}
+
+// Reload disconnects a server and connects it again with its stored settings.
+// Deleted or inactive servers are only disconnected.
+func Reload(ctx context.Context, serverId string) error {
+	Stop(serverId)
+	var server *entity.McpServer
+	err := dao.McpServer.Ctx(ctx).Where(do.McpServer{Id: serverId, IsActive: 1}).Scan(&server)
+	if err != nil {
+		return err
+	}
+	if server == nil {
+		return nil
+	}
+	return start(ctx, server)
+}
+
+// Stop disconnects a server and unregisters its tools.
+func Stop(serverId string) {
+	mu.Lock()
+	conn, ok := connections[serverId]
+	delete(connections, serverId)
+	mu.Unlock()
+	if !ok {
+		return
+	}
+	close(conn.stopped)
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	for _, name := range conn.tools {
+		llm.UnregisterTool(name)
+	}
+	if conn.session != nil {
+		_ = conn.session.Close()
+	}
+}
+
+// GetStatus reports whether the server is connected and which tools it
+// exposes.
+func GetStatus(serverId string) Status {
+	mu.Lock()
+	conn, ok := connections[serverId]
+	mu.Unlock()
+	if !ok {
+		return Status{Tools: []string{}}
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	status := Status{
+		Connected: conn.session != nil,
+		Tools:     append([]string{}, conn.tools...),
+	}
+	if conn.err != nil {
+		status.Error = conn.err.Error()
+	}
+	return status
+}
+
+func start(ctx context.Context, server *entity.McpServer) error {
+	config, err := ParseConfig(server)
+	if err != nil {
+		return err
+	}
+	conn := &connection{server: server, config: config, stopped: make(chan struct{})}
+	mu.Lock()
+	connections[server.Id] = conn
+	mu.Unlock()
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	conn.err = conn.setup(ctx)
+	if conn.err != nil {
+		go conn.reconnect(context.WithoutCancel(ctx))
+	}
+	return conn.err
+}
+
+// setup connects when needed and registers the tools. The caller holds
+// conn.mu.
+func (conn *connection) setup(ctx context.Context) error {
+	if conn.session == nil {
+		if err := conn.connect(ctx); err != nil {
+			return err
+		}
+	}
+	return conn.refreshTools(ctx)
+}
+
+// reconnect retries the setup of a connection that failed, with a growing
+// delay, until it succeeds or the server is stopped.
+func (conn *connection) reconnect(ctx context.Context) {
+	delay := reconnectMinDelay
+	for {
+		timer := time.NewTimer(delay)
+		select {
+		case <-conn.stopped:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		conn.mu.Lock()
+		if !conn.active() {
+			conn.mu.Unlock()
+			return
+		}
+		conn.err = conn.setup(ctx)
+		err := conn.err
+		conn.mu.Unlock()
+		if err == nil {
+			g.Log().Infof(ctx, "Reconnected MCP server %s", conn.server.Name)
+			return
+		}
+		g.Log().Debugf(ctx, "Failed to reconnect MCP server %s: %v", conn.server.Name, err)
+		delay = min(delay*2, reconnectMaxDelay)
+	}
+}
+
+// active reports whether the connection is still the registered one of its
+// server.
+func (conn *connection) active() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return connections[conn.server.Id] == conn
+}
+
+// connect opens a session with the server. The caller holds conn.mu.
+func (conn *connection) connect(ctx context.Context) error {
+	client := sdk.NewClient(&sdk.Implementation{Name: "flai", Version: "1.0.0"}, &sdk.ClientOptions{
+		ToolListChangedHandler: func(ctx context.Context, req *sdk.ToolListChangedRequest) {
+			go func() {
+				ctx := context.WithoutCancel(ctx)
+				conn.mu.Lock()
+				defer conn.mu.Unlock()
+				if err := conn.refreshTools(ctx); err != nil {
+					g.Log().Warningf(ctx, "Failed to refresh tools of MCP server %s: %v", conn.server.Name, err)
+				}
+			}()
+		},
+	})
+
+	var transport sdk.Transport
+	switch conn.server.Transport {
+	case consts.McpTransport.Stdio:
+		cmd := exec.Command(conn.server.Command, conn.config.Args...)
+		cmd.Env = os.Environ()
+		for key, value := range conn.config.Env {
+			cmd.Env = append(cmd.Env, key+"="+value)
+		}
+		transport = &sdk.CommandTransport{Command: cmd}
+	case consts.McpTransport.Http:
+		transport = &sdk.StreamableClientTransport{
+			Endpoint: conn.server.Url,
+			HTTPClient: &http.Client{
+				Transport: &headerTransport{headers: conn.config.Headers, base: http.DefaultTransport},
+			},
+		}
+	default:
+		return gerror.Newf("unsupported MCP transport: %s", conn.server.Transport)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+	session, err := client.Connect(ctx, transport, nil)
+	if err != nil {
+		return err
+	}
+	conn.session = session
+	return nil
+}
+
+// refreshTools lists the tools of the server and registers the allowed ones.
+// Stopped connections register nothing. The caller holds conn.mu.
+func (conn *connection) refreshTools(ctx context.Context) error {
+	if !conn.active() {
+		return nil
+	}
+	if conn.session == nil {
+		return gerror.New("not connected")
+	}
+	var tools []*sdk.Tool
+	for tool, err := range conn.session.Tools(ctx, nil) {
+		if err != nil {
+			return err
+		}
+		if len(conn.config.AllowedTools) > 0 && !slices.Contains(conn.config.AllowedTools, tool.Name) {
+			continue
+		}
+		tools = append(tools, tool)
+	}
+
+	for _, name := range conn.tools {
+		llm.UnregisterTool(name)
+	}
+	conn.tools = conn.tools[:0]
+	for _, tool := range tools {
+		parameters, err := schemaMap(tool.InputSchema)
+		if err != nil {
+			return err
+		}
+		llmTool := &llm.Tool{
+			Name:        ToolName(conn.server.Name, tool.Name),
+			Description: tool.Description,
+			Parameters:  parameters,
+			Timeout:     time.Duration(conn.server.Timeout) * time.Second,
+			Execute:     conn.executor(tool.Name),
+		}
+		llm.RegisterTool(llmTool)
+		conn.tools = append(conn.tools, llmTool.Name)
+	}
+	return nil
+}
+
+// executor returns the function calling a tool on the server. A closed
+// session is reconnected once before the call fails.
+func (conn *connection) executor(toolName string) func(ctx context.Context, arguments string) (*llm.ToolOutput, error) {
+	return func(ctx context.Context, arguments string) (*llm.ToolOutput, error) {
+		params := &sdk.CallToolParams{Name: toolName}
+		if arguments != "" {
+			params.Arguments = json.RawMessage(arguments)
+		}
+
+		conn.mu.Lock()
+		session := conn.session
+		conn.mu.Unlock()
+		var result *sdk.CallToolResult
+		var err error
+		if session != nil {
+			result, err = session.CallTool(ctx, params)
+		}
+		if session == nil || gerror.Is(err, sdk.ErrConnectionClosed) {
+			conn.mu.Lock()
+			if conn.session == session {
+				conn.session = nil
+				conn.err = conn.connect(ctx)
+			}
+			session = conn.session
+			conn.mu.Unlock()
+			if session == nil {
+				return nil, gerror.Newf("MCP server %s is not connected", conn.server.Name)
+			}
+			result, err = session.CallTool(ctx, params)
+		}
+		if err != nil {
+			return nil, err
+		}
+		text := resultText(result)
+		if result.IsError {
+			return nil, gerror.New(text)
+		}
+		return &llm.ToolOutput{Content: text}, nil
+	}
+}
+
+// resultText flattens the content of a tool result into text for the model.
+func resultText(result *sdk.CallToolResult) string {
+	var parts []string
+	for _, content := range result.Content {
+		switch c := content.(type) {
+		case *sdk.TextContent:
+			parts = append(parts, c.Text)
+		case *sdk.ImageContent:
+			parts = append(parts, "[image: "+c.MIMEType+"]")
+		case *sdk.AudioContent:
+			parts = append(parts, "[audio: "+c.MIMEType+"]")
+		case *sdk.ResourceLink:
+			parts = append(parts, "[resource: "+c.URI+"]")
+		case *sdk.EmbeddedResource:
+			if c.Resource != nil && c.Resource.Text != "" {
+				parts = append(parts, c.Resource.Text)
+			} else if c.Resource != nil {
+				parts = append(parts, "[resource: "+c.Resource.URI+"]")
+			}
+		}
+	}
+	if len(parts) == 0 && result.StructuredContent != nil {
+		if data, err := json.Marshal(result.StructuredContent); err == nil {
+			parts = append(parts, string(data))
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+func schemaMap(schema any) (map[string]any, error) {
+	if schema == nil {
+		return map[string]any{"type": "object"}, nil
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	var parameters map[string]any
+	if err = json.Unmarshal(data, &parameters); err != nil {
+		return nil, err
+	}
+	return parameters, nil
+}
+
+var toolNameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// ToolName builds the name a server tool is registered under. Providers only
+// accept letters, digits, underscores and dashes, up to 64 characters.
+func ToolName(serverName string, toolName string) string {
+	name := "mcp_" + toolNameInvalidChars.ReplaceAllString(serverName, "_") + "_" + toolNameInvalidChars.ReplaceAllString(toolName, "_")
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+type headerTransport struct {
+	headers map[string]string
+	base    http.RoundTripper
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(t.headers) == 0 {
+		return t.base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	for key, value := range t.headers {
+		req.Header.Set(key, value)
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,200 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"flai/internal/consts"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+	"math/rand/v2"
+	"slices"
+	"sync/atomic"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// aliasMap alias name -> alias, replaced as a whole on every update
+var aliasMap atomic.Pointer[map[string]*ModelAlias]
+
+// AliasTarget is a model an alias routes to. Roles limits the target to
+// users with one of the roles, it is open to all users when empty.
+type AliasTarget struct {
+	ProviderId string   `json:"provider_id"`
+	ModelName  string   `json:"model_name"`
+	Weight     int      `json:"weight,omitempty"`
+	Roles      []string `json:"roles,omitempty"`
+}
+
+// ModelAlias is a virtual model resolved to one of its targets per request.
+type ModelAlias struct {
+	Name        string
+	DisplayName string
+	Description string
+	Strategy    string
+	Targets     []*AliasTarget
+}
+
+// GetModelAlias returns the active alias with the name, or nil.
+func GetModelAlias(name string) *ModelAlias {
+	return ModelAliases()[name]
+}
+
+// ModelAliases returns the active aliases by name. The map must not be
+// modified.
+func ModelAliases() map[string]*ModelAlias {
+	if aliases := aliasMap.Load(); aliases != nil {
+		return *aliases
+	}
+	return nil
+}
+
+// UpdateModelAliasCache reloads the active aliases. The previous aliases stay
+// in use when loading fails.
+func UpdateModelAliasCache(ctx context.Context) error {
+	var aliasList []*entity.ModelAlias
+	err := g.DB().Model(&entity.ModelAlias{}).Where(do.ModelAlias{IsActive: 1}).Scan(&aliasList)
+	if err != nil {
+		return err
+	}
+
+	aliases := make(map[string]*ModelAlias)
+	for _, alias := range aliasList {
+		var targets []*AliasTarget
+		if err := json.Unmarshal([]byte(alias.Targets), &targets); err != nil {
+			g.Log().Errorf(ctx, "Model alias %s targets unmarshal err: %v", alias.Name, err)
+			continue
+		}
+		aliases[alias.Name] = &ModelAlias{
+			Name:        alias.Name,
+			DisplayName: alias.DisplayName,
+			Description: alias.Description,
+			Strategy:    alias.Strategy,
+			Targets:     targets,
+		}
+	}
+	aliasMap.Store(&aliases)
+	g.Log().Infof(ctx, "Model alias cache updated")
+	return nil
+}
+
+// Available reports whether a target of the alias is open to the role.
+func (a *ModelAlias) Available(role string) bool {
+	for _, target := range a.Targets {
+		if target.open(role) && target.model() != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Config describes the alias as a model. It only claims the capabilities and
+// limits all of its targets share, so any of them can answer.
+func (a *ModelAlias) Config() *ModelConfig {
+	config := &ModelConfig{
+		ID:   a.Name,
+		Name: a.DisplayName,
+	}
+	if config.Name == "" {
+		config.Name = a.Name
+	}
+	first := true
+	for _, target := range a.Targets {
+		model := target.model()
+		if model == nil {
+			continue
+		}
+		if first {
+			config.Attachment = model.Attachment
+			config.Reasoning = model.Reasoning
+			config.ToolCall = model.ToolCall
+			config.StructuredOutput = model.StructuredOutput
+			config.Temperature = model.Temperature
+			config.Modalities.Input = slices.Clone(model.Modalities.Input)
+			config.Modalities.Output = slices.Clone(model.Modalities.Output)
+			config.Limit = model.Limit
+			first = false
+			continue
+		}
+		config.Attachment = config.Attachment && model.Attachment
+		config.Reasoning = config.Reasoning && model.Reasoning
+		config.ToolCall = config.ToolCall && model.ToolCall
+		config.StructuredOutput = config.StructuredOutput && model.StructuredOutput
+		config.Temperature = config.Temperature && model.Temperature
+		config.Modalities.Input = slices.DeleteFunc(config.Modalities.Input, func(modality string) bool {
+			return !slices.Contains(model.Modalities.Input, modality)
+		})
+		config.Modalities.Output = slices.DeleteFunc(config.Modalities.Output, func(modality string) bool {
+			return !slices.Contains(model.Modalities.Output, modality)
+		})
+		config.Limit.Context = min(config.Limit.Context, model.Limit.Context)
+		config.Limit.Output = min(config.Limit.Output, model.Limit.Output)
+	}
+	return config
+}
+
+// Resolve picks the model answering a request of a user with the role,
+// among the active targets open to the role that accept the request. A nil
+// accept takes every target.
+func (a *ModelAlias) Resolve(role string, accept func(*SimpleProviderInfo, *ModelConfig) bool) (*SimpleProviderInfo, *ModelConfig, error) {
+	var targets []*AliasTarget
+	available := false
+	for _, target := range a.Targets {
+		if !target.open(role) || target.model() == nil {
+			continue
+		}
+		available = true
+		if accept == nil || accept(ProviderMap[target.ProviderId], target.model()) {
+			targets = append(targets, target)
+		}
+	}
+	if !available {
+		return nil, nil, gerror.Newf("no model of alias %s is available", a.Name)
+	}
+	if len(targets) == 0 {
+		return nil, nil, gerror.Newf("no model of alias %s supports the settings and attachments", a.Name)
+	}
+
+	picked := targets[0]
+	switch a.Strategy {
+	case consts.AliasStrategy.Cheapest:
+		for _, target := range targets[1:] {
+			if target.model().Cost.cheaperThan(picked.model().Cost) {
+				picked = target
+			}
+		}
+	default:
+		total := 0
+		for _, target := range targets {
+			total += max(1, target.Weight)
+		}
+		n := rand.IntN(total)
+		for _, target := range targets {
+			n -= max(1, target.Weight)
+			if n < 0 {
+				picked = target
+				break
+			}
+		}
+	}
+	return ProviderMap[picked.ProviderId], picked.model(), nil
+}
+
+func (t *AliasTarget) open(role string) bool {
+	return len(t.Roles) == 0 || slices.Contains(t.Roles, role)
+}
+
+// model returns the config of the target, or nil when its provider or model
+// is inactive.
+func (t *AliasTarget) model() *ModelConfig {
+	providerInfo := ProviderMap[t.ProviderId]
+	if providerInfo == nil {
+		return nil
+	}
+	return providerInfo.ModelIdMap[t.ModelName]
+}
+
+// cheaperThan compares the price of a million input and output tokens.
+func (c Cost) cheaperThan(other Cost) bool {
+	return c.Input+c.Output < other.Input+other.Output
+}
@@ -48,10 +48,12 @@ type Modalities struct {
 }
 
 type SimpleProviderInfo struct {
+	Id           string
 	ProviderType string
 	Name         string
 	BaseUrl      string
 	ApiKey       string
+	KeySelection string
 	ModelIdMap   map[string]*ModelConfig
 }
 
@@ -70,10 +72,12 @@ func UpdateProviderCache(ctx context.Context) {
 		}
 		if _, ok := ProviderMap[provider.Id]; !ok {
 			modelInfo := SimpleProviderInfo{
+				Id:           provider.Id,
 				ProviderType: provider.ProviderType,
 				Name:         provider.Name,
 				ApiKey:       provider.ApiKey,
 				BaseUrl:      provider.BaseUrl,
+				KeySelection: provider.KeySelection,
 				ModelIdMap:   make(map[string]*ModelConfig),
 			}
 			ProviderMap[provider.Id] = &modelInfo
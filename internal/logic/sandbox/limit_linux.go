@@ -0,0 +1,13 @@
+//go:build linux
+
+package sandbox
+
+import "syscall"
+
+// limitMemory caps the data segment of the process, which on Linux includes
+// every private writable mapping and so the Go heap. Allocations past the
+// limit fail and the Go runtime aborts the process. The address space is not
+// limited because the runtime reserves far more of it than it uses.
+func limitMemory(size uint64) error {
+	return syscall.Setrlimit(syscall.RLIMIT_DATA, &syscall.Rlimit{Cur: size, Max: size})
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+
+package sandbox
+
+import "github.com/gogf/gf/v2/errors/gerror"
+
+// limitMemory is only implemented on Linux, other systems cannot run scripts
+// with a memory limit.
+func limitMemory(size uint64) error {
+	return gerror.New("the code interpreter requires Linux")
+}
@@ -0,0 +1,267 @@
+package sandbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flai/internal/logic/attachment"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"runtime/metrics"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+const (
+	defaultTimeout      = 10 * time.Second
+	defaultMemoryLimit  = 64 << 20
+	defaultOutputLimit  = 64 << 10
+	defaultMaxFiles     = 10
+	defaultMaxFilesSize = 10 << 20
+	defaultConcurrency  = 2
+	maxCallStackSize    = 1024
+	memoryCheckInterval = 10 * time.Millisecond
+	toolTimeoutMargin   = 5 * time.Second
+	heapMetric          = "/memory/classes/heap/objects:bytes"
+)
+
+// Limits bounds a single run, read from the codeInterpreter config section.
+type Limits struct {
+	Timeout      time.Duration
+	MemoryLimit  uint64
+	OutputLimit  int
+	MaxFiles     int
+	MaxFilesSize int
+}
+
+// File is a file written by the script.
+type File struct {
+	Name     string
+	MimeType string
+	Data     []byte
+}
+
+// Result is the outcome of a run. Error is set when the script threw or was
+// stopped, the output and files produced until then are kept.
+type Result struct {
+	Output          string
+	OutputTruncated bool
+	Value           string
+	Error           string
+	Files           []*File
+}
+
+// GetLimits returns the configured limits.
+func GetLimits(ctx context.Context) *Limits {
+	return &Limits{
+		Timeout:      g.Cfg().MustGet(ctx, "codeInterpreter.timeout", defaultTimeout).Duration(),
+		MemoryLimit:  g.Cfg().MustGet(ctx, "codeInterpreter.memoryLimit", defaultMemoryLimit).Uint64(),
+		OutputLimit:  g.Cfg().MustGet(ctx, "codeInterpreter.outputLimit", defaultOutputLimit).Int(),
+		MaxFiles:     g.Cfg().MustGet(ctx, "codeInterpreter.maxFiles", defaultMaxFiles).Int(),
+		MaxFilesSize: g.Cfg().MustGet(ctx, "codeInterpreter.maxFilesSize", defaultMaxFilesSize).Int(),
+	}
+}
+
+// execute runs JavaScript in a fresh runtime of the worker process. The
+// runtime has no access to the network, the file system or the host; scripts
+// only get console and writeFile. The worker runs one script, so the heap
+// check in watch only sees the memory of this run; allocations the check
+// cannot interrupt are stopped by the address space limit of the process.
+func execute(ctx context.Context, limits *Limits, code string) (*Result, error) {
+	vm := goja.New()
+	vm.SetMaxCallStackSize(maxCallStackSize)
+	result := &Result{}
+	output := &strings.Builder{}
+	env := &environment{vm: vm, limits: limits, result: result, output: output}
+	if err := env.install(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, limits.Timeout)
+	defer cancel()
+	done := make(chan struct{})
+	defer close(done)
+	go watch(ctx, vm, limits.MemoryLimit, done)
+
+	value, err := vm.RunString(code)
+	result.Output = output.String()
+	if err != nil {
+		result.Error = errorText(err)
+		return result, nil
+	}
+	if value != nil && !goja.IsUndefined(value) && !goja.IsNull(value) {
+		result.Value = env.format(value)
+		// The value shares the output budget with the console.
+		if remaining := limits.OutputLimit - output.Len(); len(result.Value) > remaining {
+			result.Value = strings.ToValidUTF8(result.Value[:max(0, remaining)], "")
+			result.OutputTruncated = true
+		}
+	}
+	return result, nil
+}
+
+// watch interrupts the script when the context ends or the live heap grows
+// beyond the memory limit.
+func watch(ctx context.Context, vm *goja.Runtime, memoryLimit uint64, done chan struct{}) {
+	sample := []metrics.Sample{{Name: heapMetric}}
+	metrics.Read(sample)
+	base := sample[0].Value.Uint64()
+	ticker := time.NewTicker(memoryCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				vm.Interrupt("time limit exceeded")
+			} else {
+				vm.Interrupt("execution cancelled")
+			}
+			return
+		case <-ticker.C:
+			if !exceeds(sample, base, memoryLimit) {
+				continue
+			}
+			runtime.GC()
+			if exceeds(sample, base, memoryLimit) {
+				vm.Interrupt("memory limit exceeded")
+				return
+			}
+		}
+	}
+}
+
+// exceeds reads the heap into sample and reports whether it grew more than
+// limit over base.
+func exceeds(sample []metrics.Sample, base, limit uint64) bool {
+	metrics.Read(sample)
+	heap := sample[0].Value.Uint64()
+	return heap > base && heap-base > limit
+}
+
+func errorText(err error) string {
+	var interrupted *goja.InterruptedError
+	if errors.As(err, &interrupted) {
+		return fmt.Sprintf("Execution stopped: %v", interrupted.Value())
+	}
+	var overflow *goja.StackOverflowError
+	if errors.As(err, &overflow) {
+		return "RangeError: Maximum call stack size exceeded\n\t" + strings.TrimSpace(overflow.Error())
+	}
+	var exception *goja.Exception
+	if errors.As(err, &exception) {
+		return strings.TrimSuffix(exception.String(), "\n")
+	}
+	return err.Error()
+}
+
+// environment holds the globals of a run.
+type environment struct {
+	vm        *goja.Runtime
+	limits    *Limits
+	result    *Result
+	output    *strings.Builder
+	filesSize int
+}
+
+func (e *environment) install() error {
+	console := e.vm.NewObject()
+	for _, name := range []string{"log", "info", "debug", "warn", "error"} {
+		if err := console.Set(name, e.print); err != nil {
+			return err
+		}
+	}
+	if err := e.vm.Set("console", console); err != nil {
+		return err
+	}
+	return e.vm.Set("writeFile", e.writeFile)
+}
+
+func (e *environment) print(call goja.FunctionCall) goja.Value {
+	parts := make([]string, 0, len(call.Arguments))
+	for _, arg := range call.Arguments {
+		parts = append(parts, e.format(arg))
+	}
+	line := strings.Join(parts, " ") + "\n"
+	if e.result.OutputTruncated {
+		return goja.Undefined()
+	}
+	if remaining := e.limits.OutputLimit - e.output.Len(); len(line) > remaining {
+		e.output.WriteString(strings.ToValidUTF8(line[:max(0, remaining)], ""))
+		e.result.OutputTruncated = true
+		return goja.Undefined()
+	}
+	e.output.WriteString(line)
+	return goja.Undefined()
+}
+
+// format prints strings as they are and other values as JSON where possible.
+func (e *environment) format(value goja.Value) string {
+	if _, ok := value.Export().(string); ok {
+		return value.String()
+	}
+	if object, ok := value.(*goja.Object); ok {
+		if _, isFunction := goja.AssertFunction(object); !isFunction {
+			if data, err := json.Marshal(object); err == nil {
+				return string(data)
+			}
+		}
+	}
+	return value.String()
+}
+
+// throw raises a JavaScript Error the script can catch.
+func (e *environment) throw(message string) {
+	errorConstructor, _ := goja.AssertConstructor(e.vm.Get("Error"))
+	value, err := errorConstructor(nil, e.vm.ToValue(message))
+	if err != nil {
+		panic(err)
+	}
+	panic(value)
+}
+
+// writeFile(name, data) stores a file for the user. Data may be a string, an
+// ArrayBuffer or a Uint8Array. Writing a name again replaces the file.
+func (e *environment) writeFile(name string, data goja.Value) {
+	name = filepath.Base(strings.TrimSpace(name))
+	if name == "" || name == "." || name == string(filepath.Separator) {
+		panic(e.vm.NewTypeError("writeFile: invalid file name"))
+	}
+	var content []byte
+	switch exported := data.Export().(type) {
+	case string:
+		content = []byte(exported)
+	case goja.ArrayBuffer:
+		content = append([]byte(nil), exported.Bytes()...)
+	case []byte:
+		content = append([]byte(nil), exported...)
+	default:
+		panic(e.vm.NewTypeError("writeFile: data must be a string, ArrayBuffer or Uint8Array"))
+	}
+
+	files := e.result.Files
+	for i, file := range files {
+		if file.Name == name {
+			e.filesSize -= len(file.Data)
+			files = append(files[:i], files[i+1:]...)
+			break
+		}
+	}
+	if len(files) >= e.limits.MaxFiles {
+		e.throw(fmt.Sprintf("writeFile: at most %d files can be written", e.limits.MaxFiles))
+	}
+	if e.filesSize+len(content) > e.limits.MaxFilesSize {
+		e.throw(fmt.Sprintf("writeFile: files exceed %d bytes", e.limits.MaxFilesSize))
+	}
+	e.filesSize += len(content)
+	e.result.Files = append(files, &File{
+		Name:     name,
+		MimeType: attachment.DetectMimeType(name, content),
+		Data:     content,
+	})
+}
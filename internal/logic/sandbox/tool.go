@@ -0,0 +1,96 @@
+package sandbox
+
+import (
+	"context"
+	"encoding/json"
+	"flai/internal/consts"
+	"flai/internal/logic/llm"
+	"fmt"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// Register adds the code interpreter tool unless it is disabled.
+func Register(ctx context.Context) {
+	if !g.Cfg().MustGet(ctx, "codeInterpreter.enabled", true).Bool() {
+		return
+	}
+	slots = make(chan struct{}, max(1, g.Cfg().MustGet(ctx, "codeInterpreter.concurrency", defaultConcurrency).Int()))
+	limits := GetLimits(ctx)
+	llm.RegisterTool(&llm.Tool{
+		Name: consts.InternalTools.RunCode,
+		Description: fmt.Sprintf("Run JavaScript (ES6+, no modules) for calculations and data analysis. "+
+			"Print results with console.log; the value of the last expression is returned as well. "+
+			"Create files for the user with writeFile(name, data), where data is a string, ArrayBuffer or Uint8Array. "+
+			"There is no network, file system, timer or module access. Runs are limited to %s and %d MB of memory.",
+			limits.Timeout, limits.MemoryLimit>>20),
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"code": map[string]any{
+					"type":        "string",
+					"description": "JavaScript source to run",
+				},
+			},
+			"required": []string{"code"},
+		},
+		// Leave room for the run to report its own time limit
+		Timeout: limits.Timeout + toolTimeoutMargin,
+		Execute: func(ctx context.Context, arguments string) (*llm.ToolOutput, error) {
+			var args struct {
+				Code string `json:"code"`
+			}
+			if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+				return nil, err
+			}
+			if strings.TrimSpace(args.Code) == "" {
+				return nil, gerror.New("code is required")
+			}
+			result, err := Run(ctx, limits, args.Code)
+			if err != nil {
+				return nil, err
+			}
+			output := &llm.ToolOutput{Content: resultText(result)}
+			for _, file := range result.Files {
+				output.Files = append(output.Files, llm.ToolFile{
+					Name:     file.Name,
+					MimeType: file.MimeType,
+					Data:     file.Data,
+				})
+			}
+			return output, nil
+		},
+	})
+}
+
+// resultText summarizes a run for the model.
+func resultText(result *Result) string {
+	sb := strings.Builder{}
+	if result.Output != "" {
+		sb.WriteString("Output:\n")
+		sb.WriteString(strings.TrimSuffix(result.Output, "\n"))
+		if result.OutputTruncated {
+			sb.WriteString("\n[Output truncated]")
+		}
+		sb.WriteString("\n")
+	}
+	if result.Value != "" {
+		sb.WriteString("Result: ")
+		sb.WriteString(result.Value)
+		sb.WriteString("\n")
+	}
+	if result.Error != "" {
+		sb.WriteString("Error: ")
+		sb.WriteString(result.Error)
+		sb.WriteString("\n")
+	}
+	for _, file := range result.Files {
+		sb.WriteString(fmt.Sprintf("File %s (%s, %d bytes) was attached to the answer.\n", file.Name, file.MimeType, len(file.Data)))
+	}
+	if sb.Len() == 0 {
+		return "The code ran without output."
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,138 @@
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	// WorkerCommand is the command of the flai binary that runs one script.
+	WorkerCommand = "sandbox"
+	// workerResultFd is the pipe the worker writes its result to. Stdout is
+	// left to the logger.
+	workerResultFd = 3
+	// dataOverhead is the memory the Go runtime and the interpreter of the
+	// worker need besides the memory of the script.
+	dataOverhead      = 256 << 20
+	workerStartMargin = 2 * time.Second
+	workerStderrLimit = 4 << 10
+)
+
+type workerRequest struct {
+	Limits *Limits
+	Code   string
+}
+
+// slots limits how many scripts run at the same time.
+var slots chan struct{}
+
+// Run executes JavaScript in a new worker process. The writable memory of the
+// worker is limited to the memory limit plus dataOverhead, so a script that
+// allocates past it only kills its own process; the output and files of such
+// a run are lost. Scripts that grow gradually are stopped with an error by the
+// heap check in the worker before that.
+func Run(ctx context.Context, limits *Limits, code string) (*Result, error) {
+	select {
+	case slots <- struct{}{}:
+		defer func() { <-slots }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	request, err := json.Marshal(&workerRequest{Limits: limits, Code: code})
+	if err != nil {
+		return nil, err
+	}
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	workerCtx, cancel := context.WithTimeout(ctx, limits.Timeout+workerStartMargin)
+	defer cancel()
+	cmd := exec.CommandContext(workerCtx, executable, WorkerCommand)
+	cmd.Env = []string{}
+	cmd.Stdin = bytes.NewReader(request)
+	stderr := &limitedBuffer{limit: workerStderrLimit}
+	cmd.Stderr = stderr
+	cmd.ExtraFiles = []*os.File{writer}
+	err = cmd.Start()
+	writer.Close()
+	if err != nil {
+		return nil, err
+	}
+	data, readErr := io.ReadAll(io.LimitReader(reader, maxResultSize(limits)))
+	waitErr := cmd.Wait()
+
+	result := &Result{}
+	if readErr == nil && waitErr == nil && json.Unmarshal(data, result) == nil {
+		return result, nil
+	}
+	switch {
+	case ctx.Err() != nil:
+		result.Error = "Execution stopped: execution cancelled"
+	case errors.Is(workerCtx.Err(), context.DeadlineExceeded):
+		result.Error = "Execution stopped: time limit exceeded"
+	case strings.Contains(stderr.String(), "out of memory") || strings.Contains(stderr.String(), "cannot allocate memory"):
+		result.Error = "Execution stopped: memory limit exceeded"
+	default:
+		return nil, gerror.Wrapf(errors.Join(waitErr, readErr), "sandbox worker failed: %s", strings.TrimSpace(stderr.String()))
+	}
+	return result, nil
+}
+
+// Serve is the main function of the worker. It reads one request from stdin,
+// limits its own memory, runs the script and writes the result to
+// workerResultFd.
+func Serve(ctx context.Context) error {
+	var request workerRequest
+	if err := json.NewDecoder(os.Stdin).Decode(&request); err != nil {
+		return err
+	}
+	if request.Limits == nil {
+		return gerror.New("missing limits")
+	}
+	if err := limitMemory(request.Limits.MemoryLimit + dataOverhead); err != nil {
+		return err
+	}
+	result, err := execute(ctx, request.Limits, request.Code)
+	if err != nil {
+		return err
+	}
+	output := os.NewFile(workerResultFd, "result")
+	defer output.Close()
+	return json.NewEncoder(output).Encode(result)
+}
+
+// maxResultSize bounds the encoded result of a run: output and value escaped
+// as JSON, and the files in base64.
+func maxResultSize(limits *Limits) int64 {
+	return int64(limits.OutputLimit)*12 + int64(limits.MaxFilesSize)*2 + 1<<20
+}
+
+// limitedBuffer keeps the first limit bytes written to it.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); room > 0 {
+		b.Buffer.Write(p[:min(len(p), room)])
+	}
+	return len(p), nil
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// Storage types
+const (
+	TypeLocal = "local"
+	TypeS3    = "s3"
+)
+
+var (
+	// ErrNotFound is returned when the object does not exist.
+	ErrNotFound = errors.New("object not found")
+	// ErrSignNotSupported is returned by backends that cannot hand out
+	// direct download urls; callers serve the object through flai instead.
+	ErrSignNotSupported = errors.New("signed urls not supported")
+)
+
+// Storage is a blob store for attachments, avatars and generated images.
+// Keys are slash separated and chosen by the caller.
+type Storage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Exists(ctx context.Context, key string) (bool, error)
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns a time-limited url to download the object directly
+	// from the backend.
+	SignedURL(ctx context.Context, key string, fileName string, contentType string, expiry time.Duration) (string, error)
+}
+
+var (
+	defaultStorage Storage
+	defaultOnce    sync.Once
+)
+
+// Default returns the storage configured under the "storage" config key.
+func Default(ctx context.Context) Storage {
+	defaultOnce.Do(func() {
+		defaultStorage = New(ctx)
+	})
+	return defaultStorage
+}
+
+// New creates a storage from the configuration.
+func New(ctx context.Context) Storage {
+	switch storageType := g.Cfg().MustGet(ctx, "storage.type", TypeLocal).String(); storageType {
+	case TypeS3:
+		var config S3Config
+		if err := g.Cfg().MustGet(ctx, "storage.s3").Scan(&config); err != nil {
+			g.Log().Fatalf(ctx, "Invalid s3 storage config: %v", err)
+		}
+		g.Log().Infof(ctx, "Using s3 storage, bucket %s", config.Bucket)
+		return NewS3(config)
+	case TypeLocal:
+		path := g.Cfg().MustGet(ctx, "storage.local.path", "data/storage").String()
+		g.Log().Infof(ctx, "Using local storage at %s", path)
+		return NewLocal(path)
+	default:
+		g.Log().Fatalf(ctx, "Unsupported storage type: %s", storageType)
+		return nil
+	}
+}
@@ -0,0 +1,202 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flai/internal/dao"
+	"flai/internal/logic/llm"
+	"flai/internal/model/do"
+	"flai/internal/model/entity"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+const (
+	defaultTimeout         = 30 * time.Second
+	defaultMaxResponseSize = 64 << 10
+)
+
+var (
+	mu sync.Mutex
+	// registered maps webhook tool ids to the name they are registered under
+	registered = make(map[string]string)
+)
+
+// Start registers every active webhook tool.
+func Start(ctx context.Context) {
+	var tools []*entity.WebhookTool
+	err := dao.WebhookTool.Ctx(ctx).Where(do.WebhookTool{IsActive: 1}).Scan(&tools)
+	if err != nil {
+		g.Log().Errorf(ctx, "Failed to load webhook tools: %v", err)
+		return
+	}
+	for _, tool := range tools {
+		if err = register(tool); err != nil {
+			g.Log().Warningf(ctx, "Failed to register webhook tool %s: %v", tool.Name, err)
+		}
+	}
+}
+
+// Reload registers a webhook tool again with its stored settings. Deleted or
+// inactive tools are only unregistered.
+func Reload(ctx context.Context, id string) error {
+	Remove(id)
+	var tool *entity.WebhookTool
+	err := dao.WebhookTool.Ctx(ctx).Where(do.WebhookTool{Id: id, IsActive: 1}).Scan(&tool)
+	if err != nil {
+		return err
+	}
+	if tool == nil {
+		return nil
+	}
+	return register(tool)
+}
+
+// Remove unregisters a webhook tool.
+func Remove(id string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if name, ok := registered[id]; ok {
+		llm.UnregisterTool(name)
+		delete(registered, id)
+	}
+}
+
+// NameTaken reports whether the name is used by a tool other than the webhook
+// tool with the id, such as a built-in or MCP tool.
+func NameTaken(name string, id string) bool {
+	if _, ok := llm.GetTool(name); !ok {
+		return false
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	return registered[id] != name
+}
+
+func register(tool *entity.WebhookTool) error {
+	var parameters map[string]any
+	if err := json.Unmarshal([]byte(tool.Parameters), &parameters); err != nil {
+		return gerror.Wrap(err, "invalid parameters schema")
+	}
+	timeout := time.Duration(tool.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	llm.RegisterTool(&llm.Tool{
+		Name:        tool.Name,
+		Description: tool.Description,
+		Parameters:  parameters,
+		Timeout:     timeout,
+		Execute: func(ctx context.Context, arguments string) (*llm.ToolOutput, error) {
+			result, err := Call(ctx, http.DefaultClient, tool, arguments)
+			if err != nil {
+				return nil, err
+			}
+			return &llm.ToolOutput{Content: result}, nil
+		},
+	})
+	registered[tool.Id] = tool.Name
+	return nil
+}
+
+// Call sends the arguments to the endpoint of the tool and returns the
+// response body, cut to the configured maximum size.
+func Call(ctx context.Context, client *http.Client, tool *entity.WebhookTool, arguments string) (string, error) {
+	if strings.TrimSpace(arguments) == "" {
+		arguments = "{}"
+	}
+	method := strings.ToUpper(tool.Method)
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	var req *http.Request
+	var err error
+	if method == http.MethodGet {
+		endpoint, err := queryURL(tool.Url, arguments)
+		if err != nil {
+			return "", err
+		}
+		req, err = http.NewRequestWithContext(ctx, method, endpoint, nil)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		req, err = http.NewRequestWithContext(ctx, method, tool.Url, strings.NewReader(arguments))
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json, text/plain;q=0.9, */*;q=0.8")
+	if tool.AuthHeader != "" {
+		req.Header.Set(tool.AuthHeader, tool.AuthValue)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	maxSize := tool.MaxResponseSize
+	if maxSize <= 0 {
+		maxSize = defaultMaxResponseSize
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, int64(maxSize)+1))
+	if err != nil {
+		return "", err
+	}
+	truncated := len(body) > maxSize
+	if truncated {
+		body = bytes.ToValidUTF8(body[:maxSize], nil)
+	}
+	result := string(body)
+	if truncated {
+		result += fmt.Sprintf("\n[Response truncated to %d bytes]", maxSize)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", gerror.Newf("endpoint returned %s: %s", resp.Status, result)
+	}
+	return result, nil
+}
+
+// queryURL adds the top-level arguments to the query string of the endpoint.
+func queryURL(endpoint string, arguments string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	var args map[string]any
+	if err = json.Unmarshal([]byte(arguments), &args); err != nil {
+		return "", err
+	}
+	query := u.Query()
+	for key, value := range args {
+		switch v := value.(type) {
+		case string:
+			query.Set(key, v)
+		case nil:
+		default:
+			data, err := json.Marshal(v)
+			if err != nil {
+				return "", err
+			}
+			query.Set(key, string(data))
+		}
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
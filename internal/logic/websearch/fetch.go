@@ -0,0 +1,117 @@
+package websearch
+
+import (
+	"context"
+	"flai/internal/logic/extract"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	maxPageSize     = 5 << 20
+	maxPageText     = 20000
+	pageSnippetSize = 300
+)
+
+// Page is the readable content of a fetched web page.
+type Page struct {
+	Url   string
+	Title string
+	Text  string
+}
+
+// fetchClient refuses to connect to loopback, private and link-local
+// addresses so the model cannot use page fetches to reach internal services.
+// It never uses a proxy: the check only sees the address that is dialed, which
+// would be the proxy's and not the target's.
+var fetchClient = &http.Client{
+	Timeout: 30 * time.Second,
+	Transport: &http.Transport{
+		Proxy: nil,
+		DialContext: (&net.Dialer{
+			Timeout: 10 * time.Second,
+			Control: func(network, address string, c syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				ip := net.ParseIP(host)
+				if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsUnspecified() || ip.IsMulticast() {
+					return gerror.Newf("address %s is not allowed", host)
+				}
+				return nil
+			},
+		}).DialContext,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 5 {
+			return gerror.New("too many redirects")
+		}
+		return nil
+	},
+}
+
+// Fetch downloads a web page and extracts its readable text.
+func Fetch(ctx context.Context, pageUrl string) (*Page, error) {
+	u, err := url.Parse(pageUrl)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, gerror.Newf("unsupported url scheme: %s", u.Scheme)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; flai/1.0)")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,text/plain;q=0.9,*/*;q=0.5")
+	resp, err := fetchClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, gerror.Newf("page returned %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxPageSize))
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page{Url: resp.Request.URL.String()}
+	mimeType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch {
+	case mimeType == "" || mimeType == "text/html" || mimeType == "application/xhtml+xml":
+		page.Title, page.Text, err = extract.Readable(data)
+	case extract.Supported(mimeType, u.Path):
+		page.Text, err = extract.Text(mimeType, u.Path, data)
+	default:
+		err = gerror.Newf("unsupported content type: %s", mimeType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if page.Title == "" {
+		page.Title = page.Url
+	}
+	if len(page.Text) > maxPageText {
+		page.Text = strings.ToValidUTF8(page.Text[:maxPageText], "") + "\n[Page truncated]"
+	}
+	return page, nil
+}
+
+func snippet(text string) string {
+	if len(text) <= pageSnippetSize {
+		return text
+	}
+	return strings.ToValidUTF8(text[:pageSnippetSize], "") + "…"
+}
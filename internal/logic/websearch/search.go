@@ -0,0 +1,182 @@
+package websearch
+
+import (
+	"context"
+	"encoding/json"
+	"flai/internal/consts"
+	"flai/internal/logic/llm"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// Search engines
+const (
+	EngineSearXNG = "searxng"
+	EngineBrave   = "brave"
+)
+
+const (
+	defaultBraveUrl    = "https://api.search.brave.com/res/v1/web/search"
+	defaultResultCount = 5
+	maxResultCount     = 20
+	maxSearchResponse  = 4 << 20
+)
+
+// Config selects the search engine, read from the webSearch config section.
+type Config struct {
+	Engine string `json:"engine"`
+	Url    string `json:"url"`
+	ApiKey string `json:"apiKey"`
+	Count  int    `json:"count"`
+}
+
+// Result is a single search hit.
+type Result struct {
+	Title   string
+	Url     string
+	Snippet string
+}
+
+// GetConfig returns the search engine config. Engine is empty when web search
+// is not configured.
+func GetConfig(ctx context.Context) *Config {
+	config := &Config{}
+	if err := g.Cfg().MustGet(ctx, "webSearch").Scan(config); err != nil {
+		g.Log().Warningf(ctx, "Invalid web search config: %v", err)
+		return &Config{}
+	}
+	if config.Engine == EngineBrave && config.Url == "" {
+		config.Url = defaultBraveUrl
+	}
+	if config.Count <= 0 {
+		config.Count = defaultResultCount
+	}
+	return config
+}
+
+// Search queries the configured engine.
+func Search(ctx context.Context, config *Config, query string, count int) ([]*Result, error) {
+	if count <= 0 {
+		count = config.Count
+	}
+	count = min(count, maxResultCount)
+	switch config.Engine {
+	case EngineSearXNG:
+		return searchSearXNG(ctx, config, query, count)
+	case EngineBrave:
+		return searchBrave(ctx, config, query, count)
+	}
+	return nil, gerror.Newf("unsupported search engine: %s", config.Engine)
+}
+
+// searchSearXNG uses the JSON format of the SearXNG search endpoint, which
+// has to be enabled in the SearXNG settings.
+func searchSearXNG(ctx context.Context, config *Config, query string, count int) ([]*Result, error) {
+	endpoint := strings.TrimSuffix(config.Url, "/") + "/search?" + url.Values{
+		"q":      {query},
+		"format": {"json"},
+	}.Encode()
+	var body struct {
+		Results []struct {
+			Title   string `json:"title"`
+			Url     string `json:"url"`
+			Content string `json:"content"`
+		} `json:"results"`
+	}
+	if err := getJSON(ctx, endpoint, nil, &body); err != nil {
+		return nil, err
+	}
+	results := make([]*Result, 0, count)
+	for _, item := range body.Results {
+		if len(results) == count {
+			break
+		}
+		results = append(results, &Result{Title: item.Title, Url: item.Url, Snippet: item.Content})
+	}
+	return results, nil
+}
+
+func searchBrave(ctx context.Context, config *Config, query string, count int) ([]*Result, error) {
+	endpoint := config.Url + "?" + url.Values{
+		"q":     {query},
+		"count": {fmt.Sprint(count)},
+	}.Encode()
+	var body struct {
+		Web struct {
+			Results []struct {
+				Title       string `json:"title"`
+				Url         string `json:"url"`
+				Description string `json:"description"`
+			} `json:"results"`
+		} `json:"web"`
+	}
+	header := http.Header{"X-Subscription-Token": {config.ApiKey}}
+	if err := getJSON(ctx, endpoint, header, &body); err != nil {
+		return nil, err
+	}
+	results := make([]*Result, 0, count)
+	for _, item := range body.Web.Results {
+		if len(results) == count {
+			break
+		}
+		results = append(results, &Result{Title: item.Title, Url: item.Url, Snippet: stripTags(item.Description)})
+	}
+	return results, nil
+}
+
+func getJSON(ctx context.Context, endpoint string, header http.Header, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return gerror.Newf("search engine returned %s", resp.Status)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, maxSearchResponse)).Decode(target)
+}
+
+// stripTags removes the highlight markup some engines put into snippets.
+func stripTags(text string) string {
+	var sb strings.Builder
+	inTag := false
+	for _, r := range text {
+		switch {
+		case r == '<':
+			inTag = true
+		case r == '>' && inTag:
+			inTag = false
+		case !inTag:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// citations converts search results into sources for the chat.
+func citations(results []*Result) []llm.Citation {
+	list := make([]llm.Citation, 0, len(results))
+	for _, result := range results {
+		list = append(list, llm.Citation{
+			Source:  consts.CitationSource.Web,
+			Title:   result.Title,
+			Url:     result.Url,
+			Snippet: result.Snippet,
+		})
+	}
+	return list
+}
@@ -0,0 +1,95 @@
+package websearch
+
+import (
+	"context"
+	"encoding/json"
+	"flai/internal/consts"
+	"flai/internal/logic/llm"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// Register adds the web search and page fetch tools when a search engine is
+// configured.
+func Register(ctx context.Context) {
+	config := GetConfig(ctx)
+	if config.Engine == "" {
+		return
+	}
+	llm.RegisterTool(&llm.Tool{
+		Name:        consts.InternalTools.WebSearch,
+		Description: "Search the web. Returns the title, url and a snippet of the top results. Use fetch_page to read a result in full.",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"query": map[string]any{
+					"type":        "string",
+					"description": "Search query",
+				},
+				"count": map[string]any{
+					"type":        "integer",
+					"description": "Number of results, defaults to 5",
+				},
+			},
+			"required": []string{"query"},
+		},
+		Execute: func(ctx context.Context, arguments string) (*llm.ToolOutput, error) {
+			var args struct {
+				Query string `json:"query"`
+				Count int    `json:"count"`
+			}
+			if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+				return nil, err
+			}
+			if strings.TrimSpace(args.Query) == "" {
+				return nil, gerror.New("query is required")
+			}
+			results, err := Search(ctx, config, args.Query, args.Count)
+			if err != nil {
+				return nil, err
+			}
+			if len(results) == 0 {
+				return &llm.ToolOutput{Content: "No results found."}, nil
+			}
+			return &llm.ToolOutput{Citations: citations(results)}, nil
+		},
+	})
+	llm.RegisterTool(&llm.Tool{
+		Name:        consts.InternalTools.FetchPage,
+		Description: "Fetch a web page and return its main text.",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"url": map[string]any{
+					"type":        "string",
+					"description": "Absolute http or https url of the page",
+				},
+			},
+			"required": []string{"url"},
+		},
+		Execute: func(ctx context.Context, arguments string) (*llm.ToolOutput, error) {
+			var args struct {
+				Url string `json:"url"`
+			}
+			if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+				return nil, err
+			}
+			page, err := Fetch(ctx, args.Url)
+			if err != nil {
+				return nil, err
+			}
+			return &llm.ToolOutput{
+				Content: page.Text,
+				Citations: []llm.Citation{{
+					Source:  consts.CitationSource.Web,
+					Title:   page.Title,
+					Url:     page.Url,
+					Snippet: snippet(page.Text),
+				}},
+			}, nil
+		},
+	})
+	g.Log().Infof(ctx, "Web search tools registered with %s", config.Engine)
+}
@@ -0,0 +1,14 @@
+package model
+
+// AssistantDefinition is the preset of an assistant. Every version of an
+// assistant stores its own definition.
+type AssistantDefinition struct {
+	Name           string        `json:"name"`
+	Icon           string        `json:"icon"`
+	Description    string        `json:"description"`
+	ProviderId     string        `json:"provider_id" dc:"Default provider of new messages"`
+	ModelName      string        `json:"model_name" dc:"Default model of new messages"`
+	Tools          []string      `json:"tools" dc:"Tools used when a message does not choose any"`
+	KnowledgeBases []string      `json:"knowledge_bases" dc:"Knowledge bases used when a message does not choose any"`
+	Settings       *ChatSettings `json:"settings" dc:"Generation settings copied to new conversations"`
+}
@@ -0,0 +1,78 @@
+package model
+
+import "encoding/json"
+
+// ChatSettings are the generation settings of a conversation. Nil fields use
+// the defaults of the provider.
+type ChatSettings struct {
+	SystemPrompt    string   `json:"system_prompt,omitempty"`
+	Temperature     *float64 `json:"temperature,omitempty"`
+	TopP            *float64 `json:"top_p,omitempty"`
+	MaxOutputTokens *int     `json:"max_output_tokens,omitempty"`
+	StopSequences   []string `json:"stop_sequences,omitempty"`
+	// ReasoningEffort and ThinkingBudget are alternatives and are
+	// translated to the parameter the provider takes
+	ReasoningEffort string `json:"reasoning_effort,omitempty"`
+	ThinkingBudget  *int   `json:"thinking_budget,omitempty"`
+}
+
+// ParseChatSettings decodes the settings column of a conversation.
+func ParseChatSettings(data string) (*ChatSettings, error) {
+	settings := &ChatSettings{}
+	if data == "" {
+		return settings, nil
+	}
+	if err := json.Unmarshal([]byte(data), settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// String encodes the settings for the settings column.
+func (s *ChatSettings) String() string {
+	if s == nil {
+		return "{}"
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
+}
+
+// Merge returns the settings with the fields set in override replacing
+// their values.
+func (s *ChatSettings) Merge(override *ChatSettings) *ChatSettings {
+	merged := &ChatSettings{}
+	if s != nil {
+		*merged = *s
+	}
+	if override == nil {
+		return merged
+	}
+	if override.SystemPrompt != "" {
+		merged.SystemPrompt = override.SystemPrompt
+	}
+	if override.Temperature != nil {
+		merged.Temperature = override.Temperature
+	}
+	if override.TopP != nil {
+		merged.TopP = override.TopP
+	}
+	if override.MaxOutputTokens != nil {
+		merged.MaxOutputTokens = override.MaxOutputTokens
+	}
+	if override.StopSequences != nil {
+		merged.StopSequences = override.StopSequences
+	}
+	// Effort and budget are alternatives, so an override of one drops the other
+	if override.ReasoningEffort != "" {
+		merged.ReasoningEffort = override.ReasoningEffort
+		merged.ThinkingBudget = nil
+	}
+	if override.ThinkingBudget != nil {
+		merged.ThinkingBudget = override.ThinkingBudget
+		merged.ReasoningEffort = ""
+	}
+	return merged
+}
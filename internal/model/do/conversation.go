@@ -11,12 +11,15 @@ import (
 
 // Conversation is the golang structure of table conversation for DAO operations like Where/Data.
 type Conversation struct {
-	g.Meta    `orm:"table:conversation, do:true"`
-	Id        any         //
-	UserId    any         //
-	Title     any         //
-	CreatedAt *gtime.Time //
-	UpdatedAt *gtime.Time //
-	DeletedAt *gtime.Time //
-	Icon      any         //
+	g.Meta           `orm:"table:conversation, do:true"`
+	Id               any         //
+	UserId           any         //
+	Title            any         //
+	CreatedAt        *gtime.Time //
+	UpdatedAt        *gtime.Time //
+	DeletedAt        *gtime.Time //
+	Icon             any         //
+	Settings         any         //
+	AssistantId      any         //
+	AssistantVersion any         //
 }
@@ -23,4 +23,5 @@ type Provider struct {
 	UpdatedAt    *gtime.Time //
 	DeletedAt    *gtime.Time //
 	Logo         any         //
+	KeySelection any         //
 }
@@ -21,4 +21,5 @@ type Provider struct {
 	UpdatedAt    *gtime.Time `json:"updated_at"    orm:"updated_at"    description:""` //
 	DeletedAt    *gtime.Time `json:"deleted_at"    orm:"deleted_at"    description:""` //
 	Logo         string      `json:"logo"          orm:"logo"          description:""` //
+	KeySelection string      `json:"key_selection" orm:"key_selection" description:""` //
 }
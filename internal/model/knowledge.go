@@ -0,0 +1,13 @@
+package model
+
+// KnowledgeHit is a knowledge base chunk matched by a similarity search.
+// Score is the cosine similarity between the chunk and the query.
+type KnowledgeHit struct {
+	ChunkId         string  `json:"chunk_id"`
+	KnowledgeBaseId string  `json:"knowledge_base_id"`
+	DocumentId      string  `json:"document_id"`
+	FileName        string  `json:"file_name"`
+	ChunkIndex      int     `json:"chunk_index"`
+	Content         string  `json:"content"`
+	Score           float64 `json:"score"`
+}
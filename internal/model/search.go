@@ -0,0 +1,15 @@
+package model
+
+import "github.com/gogf/gf/v2/os/gtime"
+
+// SearchHit is a single full-text search match. MessageId is empty when the
+// hit is on the conversation title rather than on a message.
+type SearchHit struct {
+	ConversationId    string      `json:"conversation_id"`
+	ConversationTitle string      `json:"conversation_title"`
+	MessageId         string      `json:"message_id"`
+	Role              string      `json:"role"`
+	Snippet           string      `json:"snippet"`
+	Rank              float64     `json:"rank"`
+	CreatedAt         *gtime.Time `json:"created_at"`
+}
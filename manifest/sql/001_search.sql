@@ -0,0 +1,23 @@
+-- Full-text search over message text and conversation titles.
+--
+-- Message text lives inside the JSON content blocks, so an immutable helper
+-- extracts the plain text of every "message" block. Both GIN indexes are
+-- expression indexes and are therefore maintained by Postgres on insert and
+-- update. Queries must use exactly the same expressions to hit the indexes.
+
+CREATE OR REPLACE FUNCTION message_search_text(content jsonb) RETURNS text
+    LANGUAGE sql
+    IMMUTABLE
+    PARALLEL SAFE
+AS
+$$
+SELECT coalesce(string_agg(block -> 'data' ->> 'content', E'\n'), '')
+FROM jsonb_array_elements(CASE WHEN jsonb_typeof(content) = 'array' THEN content ELSE '[]'::jsonb END) AS block
+WHERE block ->> 'type' = 'message'
+$$;
+
+CREATE INDEX IF NOT EXISTS message_search_idx
+    ON message USING gin (to_tsvector('simple', message_search_text(content::jsonb)));
+
+CREATE INDEX IF NOT EXISTS conversation_search_idx
+    ON conversation USING gin (to_tsvector('simple', title));
@@ -0,0 +1,18 @@
+-- Public read-only share links. The shared messages are snapshotted when the
+-- link is created, so later edits to the conversation are not visible.
+
+CREATE TABLE IF NOT EXISTS share
+(
+    id              uuid PRIMARY KEY,
+    user_id         uuid        NOT NULL,
+    conversation_id uuid        NOT NULL,
+    message_id      uuid        NOT NULL,
+    title           text        NOT NULL DEFAULT '',
+    icon            text        NOT NULL DEFAULT '',
+    snapshot        jsonb       NOT NULL,
+    expires_at      timestamptz,
+    revoked_at      timestamptz,
+    created_at      timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS share_user_id_idx ON share (user_id, created_at DESC);
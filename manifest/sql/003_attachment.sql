@@ -0,0 +1,17 @@
+-- Files uploaded by users and attached to their messages. message_id stays
+-- empty until the attachment is sent with a message.
+
+CREATE TABLE IF NOT EXISTS attachment
+(
+    id         uuid PRIMARY KEY,
+    user_id    uuid        NOT NULL,
+    message_id uuid,
+    file_name  text        NOT NULL,
+    mime_type  text        NOT NULL,
+    size       bigint      NOT NULL,
+    path       text        NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS attachment_user_id_idx ON attachment (user_id);
+CREATE INDEX IF NOT EXISTS attachment_message_id_idx ON attachment (message_id);
@@ -0,0 +1,5 @@
+-- attachment.path now holds the content-addressed storage key
+-- (sha256/<2 hex>/<digest>). Identical uploads share one blob, which is only
+-- deleted once no attachment references it any more.
+
+CREATE INDEX IF NOT EXISTS attachment_path_idx ON attachment (path);
@@ -0,0 +1,76 @@
+-- Teams let users share knowledge bases with each other.
+
+CREATE TABLE IF NOT EXISTS team
+(
+    id         uuid PRIMARY KEY,
+    name       text        NOT NULL,
+    owner_id   uuid        NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    deleted_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS team_member
+(
+    team_id    uuid        NOT NULL,
+    user_id    uuid        NOT NULL,
+    role       text        NOT NULL DEFAULT 'member',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (team_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS team_member_user_id_idx ON team_member (user_id);
+
+-- Knowledge bases hold uploaded documents split into chunks and embedded with
+-- the provider model chosen when the knowledge base was created. A knowledge
+-- base belongs to a user, or to a team when team_id is set.
+
+CREATE EXTENSION IF NOT EXISTS vector;
+
+CREATE TABLE IF NOT EXISTS knowledge_base
+(
+    id              uuid PRIMARY KEY,
+    user_id         uuid        NOT NULL,
+    team_id         uuid,
+    name            text        NOT NULL,
+    description     text        NOT NULL DEFAULT '',
+    provider_id     text        NOT NULL,
+    embedding_model text        NOT NULL,
+    created_at      timestamptz NOT NULL DEFAULT now(),
+    updated_at      timestamptz NOT NULL DEFAULT now(),
+    deleted_at      timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS knowledge_base_user_id_idx ON knowledge_base (user_id);
+CREATE INDEX IF NOT EXISTS knowledge_base_team_id_idx ON knowledge_base (team_id);
+
+CREATE TABLE IF NOT EXISTS knowledge_document
+(
+    id                uuid PRIMARY KEY,
+    knowledge_base_id uuid        NOT NULL,
+    user_id           uuid        NOT NULL,
+    file_name         text        NOT NULL,
+    mime_type         text        NOT NULL,
+    size              bigint      NOT NULL,
+    chunk_count       int         NOT NULL DEFAULT 0,
+    created_at        timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS knowledge_document_knowledge_base_id_idx ON knowledge_document (knowledge_base_id);
+
+-- The embedding column has no fixed dimension because knowledge bases may use
+-- different models, so searches scan the chunks of the selected knowledge
+-- bases instead of using an ANN index.
+CREATE TABLE IF NOT EXISTS knowledge_chunk
+(
+    id                uuid PRIMARY KEY,
+    knowledge_base_id uuid        NOT NULL,
+    document_id       uuid        NOT NULL,
+    chunk_index       int         NOT NULL,
+    content           text        NOT NULL,
+    embedding         vector      NOT NULL,
+    created_at        timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS knowledge_chunk_knowledge_base_id_idx ON knowledge_chunk (knowledge_base_id);
+CREATE INDEX IF NOT EXISTS knowledge_chunk_document_id_idx ON knowledge_chunk (document_id);
@@ -0,0 +1,25 @@
+-- MCP servers registered by admins. Their tools are discovered when the
+-- server connects and offered to users as chat tools.
+--   transport = 'stdio': command and args start a subprocess, env is added to
+--                        its environment
+--   transport = 'http':  url is a streamable HTTP endpoint, headers are sent
+--                        with every request
+-- allowed_tools limits the exposed tools, all tools are exposed when empty.
+
+CREATE TABLE IF NOT EXISTS mcp_server
+(
+    id            uuid PRIMARY KEY,
+    name          text        NOT NULL,
+    transport     text        NOT NULL,
+    command       text        NOT NULL DEFAULT '',
+    args          jsonb       NOT NULL DEFAULT '[]',
+    env           jsonb       NOT NULL DEFAULT '{}',
+    url           text        NOT NULL DEFAULT '',
+    headers       jsonb       NOT NULL DEFAULT '{}',
+    allowed_tools jsonb       NOT NULL DEFAULT '[]',
+    timeout       int         NOT NULL DEFAULT 60,
+    is_active     int         NOT NULL DEFAULT 1,
+    created_at    timestamptz NOT NULL DEFAULT now(),
+    updated_at    timestamptz NOT NULL DEFAULT now(),
+    deleted_at    timestamptz
+);
@@ -0,0 +1,23 @@
+-- HTTP endpoints declared by admins as chat tools. The model's arguments are
+-- sent as the JSON request body, or as query parameters for GET, and the
+-- response body is returned to the model as the tool result.
+
+CREATE TABLE IF NOT EXISTS webhook_tool
+(
+    id                uuid PRIMARY KEY,
+    name              text        NOT NULL,
+    description       text        NOT NULL DEFAULT '',
+    parameters        jsonb       NOT NULL DEFAULT '{"type": "object"}',
+    url               text        NOT NULL,
+    method            text        NOT NULL DEFAULT 'POST',
+    auth_header       text        NOT NULL DEFAULT '',
+    auth_value        text        NOT NULL DEFAULT '',
+    timeout           int         NOT NULL DEFAULT 30,
+    max_response_size int         NOT NULL DEFAULT 65536,
+    is_active         int         NOT NULL DEFAULT 1,
+    created_at        timestamptz NOT NULL DEFAULT now(),
+    updated_at        timestamptz NOT NULL DEFAULT now(),
+    deleted_at        timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS webhook_tool_name_idx ON webhook_tool (name) WHERE deleted_at IS NULL;
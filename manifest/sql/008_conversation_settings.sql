@@ -0,0 +1,6 @@
+-- Generation settings of a conversation (system prompt, temperature, top_p,
+-- max_output_tokens, stop_sequences). Unset fields use the provider defaults
+-- and every field can be overridden per message request.
+
+ALTER TABLE conversation
+    ADD COLUMN IF NOT EXISTS settings jsonb NOT NULL DEFAULT '{}';
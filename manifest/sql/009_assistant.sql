@@ -0,0 +1,49 @@
+-- Assistants are reusable chat presets. An assistant belongs to a user, or is
+-- shared with a team when team_id is set. Every update adds a new version;
+-- assistant_version keeps all versions so conversations keep the definition
+-- they were started from.
+--   tools, knowledge_bases: jsonb arrays of tool names and knowledge base ids
+--   settings:               generation settings, same format as
+--                           conversation.settings
+
+CREATE TABLE IF NOT EXISTS assistant
+(
+    id              uuid PRIMARY KEY,
+    user_id         uuid        NOT NULL,
+    team_id         uuid,
+    version         int         NOT NULL DEFAULT 1,
+    name            text        NOT NULL,
+    icon            text        NOT NULL DEFAULT '',
+    description     text        NOT NULL DEFAULT '',
+    provider_id     text        NOT NULL DEFAULT '',
+    model_name      text        NOT NULL DEFAULT '',
+    tools           jsonb       NOT NULL DEFAULT '[]',
+    knowledge_bases jsonb       NOT NULL DEFAULT '[]',
+    settings        jsonb       NOT NULL DEFAULT '{}',
+    created_at      timestamptz NOT NULL DEFAULT now(),
+    updated_at      timestamptz NOT NULL DEFAULT now(),
+    deleted_at      timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS assistant_user_id_idx ON assistant (user_id);
+CREATE INDEX IF NOT EXISTS assistant_team_id_idx ON assistant (team_id);
+
+CREATE TABLE IF NOT EXISTS assistant_version
+(
+    assistant_id    uuid        NOT NULL,
+    version         int         NOT NULL,
+    name            text        NOT NULL,
+    icon            text        NOT NULL DEFAULT '',
+    description     text        NOT NULL DEFAULT '',
+    provider_id     text        NOT NULL DEFAULT '',
+    model_name      text        NOT NULL DEFAULT '',
+    tools           jsonb       NOT NULL DEFAULT '[]',
+    knowledge_bases jsonb       NOT NULL DEFAULT '[]',
+    settings        jsonb       NOT NULL DEFAULT '{}',
+    created_at      timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (assistant_id, version)
+);
+
+ALTER TABLE conversation
+    ADD COLUMN IF NOT EXISTS assistant_id      uuid,
+    ADD COLUMN IF NOT EXISTS assistant_version int NOT NULL DEFAULT 0;
@@ -0,0 +1,26 @@
+-- Pools of API keys per provider. Requests rotate over the active keys of a
+-- pool, and the provider's own api_key is only used when it has none.
+-- key_selection is round_robin or least_rate_limited.
+
+ALTER TABLE provider
+    ADD COLUMN IF NOT EXISTS key_selection text NOT NULL DEFAULT 'round_robin';
+
+CREATE TABLE IF NOT EXISTS provider_key
+(
+    id                   uuid PRIMARY KEY,
+    provider_id          text        NOT NULL,
+    name                 text        NOT NULL DEFAULT '',
+    api_key              text        NOT NULL,
+    is_active            int         NOT NULL DEFAULT 1,
+    request_count        bigint      NOT NULL DEFAULT 0,
+    rate_limited_count   bigint      NOT NULL DEFAULT 0,
+    error_count          bigint      NOT NULL DEFAULT 0,
+    last_used_at         timestamptz,
+    last_rate_limited_at timestamptz,
+    last_error           text        NOT NULL DEFAULT '',
+    last_error_at        timestamptz,
+    created_at           timestamptz NOT NULL DEFAULT now(),
+    updated_at           timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS provider_key_provider_idx ON provider_key (provider_id);
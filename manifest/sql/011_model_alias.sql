@@ -0,0 +1,19 @@
+-- Virtual models defined by admins. Clients send provider_id "alias" with
+-- the alias name as model_name, and each request is routed to one of the
+-- targets: [{"provider_id": "...", "model_name": "...", "weight": 1,
+-- "roles": ["admin"]}]. strategy is weighted or cheapest.
+
+CREATE TABLE IF NOT EXISTS model_alias
+(
+    id           uuid PRIMARY KEY,
+    name         text        NOT NULL,
+    display_name text        NOT NULL DEFAULT '',
+    description  text        NOT NULL DEFAULT '',
+    strategy     text        NOT NULL DEFAULT 'weighted',
+    targets      jsonb       NOT NULL DEFAULT '[]',
+    is_active    int         NOT NULL DEFAULT 1,
+    created_at   timestamptz NOT NULL DEFAULT now(),
+    updated_at   timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS model_alias_name_idx ON model_alias (name);
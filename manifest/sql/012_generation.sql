@@ -0,0 +1,23 @@
+-- Generations are answers produced by server-side jobs, detached from the
+-- request that started them. Their events are kept in memory while they run
+-- and for a while after; the answers themselves are checkpointed into
+-- message as they stream.
+--   status:      running, completed, failed or canceled
+--   message_ids: jsonb array of the ids of the answers, one per model
+
+CREATE TABLE IF NOT EXISTS generation
+(
+    id                uuid PRIMARY KEY,
+    user_id           uuid        NOT NULL,
+    conversation_id   uuid        NOT NULL,
+    prompt_message_id uuid        NOT NULL,
+    message_ids       jsonb       NOT NULL DEFAULT '[]',
+    status            text        NOT NULL DEFAULT 'running',
+    error             text        NOT NULL DEFAULT '',
+    created_at        timestamptz NOT NULL DEFAULT now(),
+    updated_at        timestamptz NOT NULL DEFAULT now(),
+    finished_at       timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS generation_conversation_id_idx ON generation (conversation_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS generation_status_idx ON generation (status) WHERE status = 'running';